@@ -2,6 +2,9 @@
 package create
 
 import (
+	"fmt"
+	"strings"
+
 	"ampaware.com/cli/internal/utils"
 	"ampaware.com/cli/pkg/aware"
 	"github.com/AlecAivazis/survey/v2"
@@ -14,6 +17,10 @@ type createParams struct {
 	parentEntity string
 	organisation string
 	displayName  string
+	attributes   map[string]string
+	identity     map[string]interface{}
+	active       bool
+	enabled      bool
 	noInput      bool
 }
 
@@ -22,6 +29,7 @@ type createCmd struct {
 	params         *createParams
 	deviceTypes    []*aware.DeviceType
 	parentEntities []*aware.Entity
+	attributes     []aware.DeviceAttribute
 }
 
 // NewCmdCreate is the create device command.
@@ -40,6 +48,10 @@ func SetFlags(cmd *cobra.Command) {
 	cmd.Flags().String("type", "", "Set the Device Type.")
 	cmd.Flags().String("parent", "", "Set the Parent Entity.")
 	cmd.Flags().String("name", "", "Set the Display Name.")
+	cmd.Flags().StringArray("attr", []string{}, "Set an attribute allowed by the Device Type, as key=value. Can be repeated.")
+	cmd.Flags().StringArray("identity", []string{}, "Set part of the Identity used by gateways, as key=value. Can be repeated.")
+	cmd.Flags().Bool("active", true, "Set whether the device is active, inactive devices are left out of lists.")
+	cmd.Flags().Bool("enabled", true, "Set whether the device is enabled.")
 }
 
 func create(cmd *cobra.Command, _ []string) {
@@ -68,6 +80,7 @@ func create(cmd *cobra.Command, _ []string) {
 	utils.ExitIfError(cc.setDeviceTypes())
 	utils.ExitIfError(cc.setParentEntities())
 	utils.ExitIfError(cc.askQuestions())
+	utils.ExitIfError(cc.setAttributes())
 
 	ID, err := func() (string, error) {
 		s := utils.ShowLoading("Creating an issue...")
@@ -78,6 +91,10 @@ func create(cmd *cobra.Command, _ []string) {
 			Organisation: params.organisation,
 			ParentEntity: params.parentEntity,
 			DeviceType:   params.deviceType,
+			IsActive:     params.active,
+			IsEnabled:    params.enabled,
+			Attributes:   cc.attributes,
+			Identity:     params.identity,
 		}
 
 		resp, err := client.CreateDevice(&cr)
@@ -110,6 +127,47 @@ func (c *createCmd) setParentEntities() error {
 	return nil
 }
 
+// setAttributes sets the attributes from the flags, asking for required attributes
+// of the device type that were not given.
+func (c *createCmd) setAttributes() error {
+	deviceType, err := func() (*aware.DeviceType, error) {
+		s := utils.ShowLoading("Fetching Device Type...")
+		defer s.Stop()
+
+		return c.client.GetDeviceTypeByID(c.params.deviceType)
+	}()
+	if err != nil {
+		return err
+	}
+
+	attributes, err := deviceType.SetAttributes(nil, c.params.attributes)
+	if err != nil {
+		return err
+	}
+
+	missing := deviceType.MissingAttributes(attributes)
+	if len(missing) > 0 && c.params.noInput {
+		return fmt.Errorf("missing required attributes: %s, set them with --attr", strings.Join(missing, ", "))
+	}
+
+	values := make(map[string]string, len(missing))
+	for _, name := range missing {
+		var ans string
+		qs := &survey.Question{
+			Name:     "attribute",
+			Prompt:   &survey.Input{Message: name + ":"},
+			Validate: survey.Required,
+		}
+		if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+			return err
+		}
+		values[name] = ans
+	}
+
+	c.attributes, err = deviceType.SetAttributes(attributes, values)
+	return err
+}
+
 func (c *createCmd) getDeviceType() *survey.Question {
 	var qs *survey.Question
 
@@ -238,10 +296,40 @@ func parseFlags(cmd *cobra.Command) *createParams {
 	displayName, err := cmd.Flags().GetString("name")
 	utils.ExitIfError(err)
 
+	attrFlag, err := cmd.Flags().GetStringArray("attr")
+	utils.ExitIfError(err)
+
+	attributes, err := utils.ParsePairs("attr", attrFlag)
+	utils.ExitIfError(err)
+
+	identityFlag, err := cmd.Flags().GetStringArray("identity")
+	utils.ExitIfError(err)
+
+	pairs, err := utils.ParsePairs("identity", identityFlag)
+	utils.ExitIfError(err)
+
+	var identity map[string]interface{}
+	if len(pairs) > 0 {
+		identity = make(map[string]interface{}, len(pairs))
+		for key, value := range pairs {
+			identity[key] = value
+		}
+	}
+
+	active, err := cmd.Flags().GetBool("active")
+	utils.ExitIfError(err)
+
+	enabled, err := cmd.Flags().GetBool("enabled")
+	utils.ExitIfError(err)
+
 	return &createParams{
 		deviceType:   deviceType,
 		parentEntity: parentEntity,
 		displayName:  displayName,
+		attributes:   attributes,
+		identity:     identity,
+		active:       active,
+		enabled:      enabled,
 		organisation: viper.GetString("organisation"),
 	}
 }
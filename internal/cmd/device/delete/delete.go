@@ -94,7 +94,6 @@ func del(cmd *cobra.Command, args []string) {
 
 // SetFlags set the flags supported by the the delete command.
 func SetFlags(cmd *cobra.Command) {
-	// TODO: Could do a cascade when doing this for entities
 	cmd.Flags().BoolP("force", "f", false, "Force the deletion of the device.")
 }
 
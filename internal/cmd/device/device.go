@@ -6,6 +6,10 @@ import (
 	"ampaware.com/cli/internal/cmd/device/delete"
 	"ampaware.com/cli/internal/cmd/device/edit"
 	"ampaware.com/cli/internal/cmd/device/list"
+	"ampaware.com/cli/internal/cmd/device/move"
+	"ampaware.com/cli/internal/cmd/device/parameter"
+	"ampaware.com/cli/internal/cmd/device/show"
+	"ampaware.com/cli/internal/cmd/device/state"
 	"ampaware.com/cli/internal/cmd/device/telemetry"
 	"github.com/spf13/cobra"
 )
@@ -23,24 +27,30 @@ func NewCmdDevice() *cobra.Command {
 
 	// TODO: Register On Cloud
 	// TODO: Edit
-	// TODO: View
-	// TODO: State?
-	// TODO: Telemetry ->
-	//  - Watch
-	// TODO: Parameter, Telemetry
-	//  - Watch
-	//  - Generate
 
 	lc := list.NewCmdList()
 	cr := create.NewCmdCreate()
 	de := delete.NewCmdDelete()
 	ed := edit.NewCmdEdit()
+	mv := move.NewCmdMove()
+	sh := show.NewCmdView()
+	en := state.NewCmdEnable()
+	di := state.NewCmdDisable()
+	ac := state.NewCmdActivate()
+	da := state.NewCmdDeactivate()
 
 	cmd.AddCommand(
 		lc,
 		cr,
 		de,
 		ed,
+		mv,
+		sh,
+		en,
+		di,
+		ac,
+		da,
+		parameter.NewCmdDeviceParameter(),
 		telemetry.NewCmdDeviceTelemetry(),
 	)
 
@@ -48,6 +58,11 @@ func NewCmdDevice() *cobra.Command {
 	create.SetFlags(cr)
 	delete.SetFlags(de)
 	edit.SetFlags(ed)
+	move.SetFlags(mv)
+	state.SetFlags(en)
+	state.SetFlags(di)
+	state.SetFlags(ac)
+	state.SetFlags(da)
 
 	return &cmd
 }
@@ -3,6 +3,7 @@ package edit
 
 import (
 	"fmt"
+	"strings"
 
 	"ampaware.com/cli/internal/utils"
 	"ampaware.com/cli/pkg/aware"
@@ -18,6 +19,10 @@ type editParams struct {
 	parentEntity string
 	organisation string
 	displayName  string
+	attributes   map[string]string
+	identity     map[string]interface{}
+	active       *bool
+	enabled      *bool
 	noInput      bool
 }
 
@@ -51,6 +56,10 @@ func SetFlags(cmd *cobra.Command) {
 	cmd.Flags().String("parent", "", "Modified Parent Entity ID.")
 	cmd.Flags().String("name", "", "Modified Display Name.")
 	cmd.Flags().String("organisation", "", "Modified Organisation ID.")
+	cmd.Flags().StringArray("attr", []string{}, "Set an attribute allowed by the Device Type, as key=value. An empty value removes it. Can be repeated.")
+	cmd.Flags().StringArray("identity", []string{}, "Replace the Identity used by gateways, as key=value. Can be repeated.")
+	cmd.Flags().Bool("active", true, "Modified active state, inactive devices are left out of lists.")
+	cmd.Flags().Bool("enabled", true, "Modified enabled state.")
 	cmd.Flags().Bool("no-input", false, "Disable prompt for non-required fields")
 }
 
@@ -107,11 +116,33 @@ func edit(cmd *cobra.Command, args []string) {
 		edit.askQuestions()
 	}
 
+	attributes, err := edit.getAttributes()
+	utils.ExitIfError(err)
+
+	identity := edit.device.Identity
+	if edit.params.identity != nil {
+		identity = edit.params.identity
+	}
+
+	active, enabled := edit.device.IsActive, edit.device.IsEnabled
+	if edit.params.active != nil {
+		active = *edit.params.active
+	}
+	if edit.params.enabled != nil {
+		enabled = *edit.params.enabled
+	}
+
+	// The update replaces every field so the unchanged ones are sent as they are
 	utils.ExitIfError(edit.client.UpdateDeviceByID(edit.device.ID, &aware.UpdateDeviceRequest{
 		DeviceType:   edit.params.deviceType,
 		ParentEntity: edit.params.parentEntity,
 		Organisation: edit.params.organisation,
 		DisplayName:  edit.params.displayName,
+		IsActive:     active,
+		IsEnabled:    enabled,
+		Attributes:   attributes,
+		Identity:     identity,
+		Credentials:  edit.device.Credentials,
 	}))
 
 	utils.Success("Device Updated")
@@ -131,6 +162,56 @@ func (e *editCmd) setDevice() error {
 	return nil
 }
 
+// getAttributes returns the device's attributes with those from the flags set, they
+// are checked against the device type the device will have.
+func (e *editCmd) getAttributes() ([]aware.DeviceAttribute, error) {
+	if len(e.params.attributes) == 0 && e.params.deviceType == e.device.DeviceType.ID {
+		return e.device.Attributes, nil
+	}
+
+	deviceType, err := func() (*aware.DeviceType, error) {
+		s := utils.ShowLoading("Fetching Device Type...")
+		defer s.Stop()
+
+		return e.client.GetDeviceTypeByID(e.params.deviceType)
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := deviceType.SetAttributes(e.device.Attributes, e.params.attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if dropped := droppedAttributes(e.device.Attributes, deviceType); len(dropped) > 0 {
+		utils.Warn("Removing attributes not allowed by %s: %s", deviceType.Name, strings.Join(dropped, ", "))
+	}
+
+	if missing := deviceType.MissingAttributes(attributes); len(missing) > 0 {
+		return nil, fmt.Errorf("missing required attributes: %s, set them with --attr", strings.Join(missing, ", "))
+	}
+
+	return attributes, nil
+}
+
+// droppedAttributes returns the names of the attributes the device type doesn't allow.
+func droppedAttributes(attributes []aware.DeviceAttribute, deviceType *aware.DeviceType) []string {
+	allowed := make(map[string]struct{}, len(deviceType.AllowedAttributes))
+	for _, a := range deviceType.AllowedAttributes {
+		allowed[a.Name] = struct{}{}
+	}
+
+	var dropped []string
+	for _, a := range attributes {
+		if _, ok := allowed[a.Name]; !ok {
+			dropped = append(dropped, a.Name)
+		}
+	}
+
+	return dropped
+}
+
 func (e *editCmd) setDevices() error {
 	s := utils.ShowLoading("Fetching Devices...")
 	defer s.Stop()
@@ -209,12 +290,12 @@ func (e *editCmd) getDevice() error {
 	for _, device := range e.devices {
 		if ans == device.ID+" - "+device.ParentEntity.GetParentHierachyName()+" - "+device.DisplayName {
 			e.params.ID = device.ID
-			e.device = device
 			break
 		}
 	}
 
-	return nil
+	// The list leaves out details the update would otherwise clear, such as the credentials
+	return e.setDevice()
 }
 
 func (e *editCmd) askQuestions() {
@@ -429,6 +510,39 @@ func parseFlagsAndArgs(cmd *cobra.Command, args []string) *editParams {
 		organisation = viper.GetString("organisation")
 	}
 
+	attrFlag, err := cmd.Flags().GetStringArray("attr")
+	utils.ExitIfError(err)
+
+	attributes, err := utils.ParsePairs("attr", attrFlag)
+	utils.ExitIfError(err)
+
+	identityFlag, err := cmd.Flags().GetStringArray("identity")
+	utils.ExitIfError(err)
+
+	pairs, err := utils.ParsePairs("identity", identityFlag)
+	utils.ExitIfError(err)
+
+	var identity map[string]interface{}
+	if len(pairs) > 0 {
+		identity = make(map[string]interface{}, len(pairs))
+		for key, value := range pairs {
+			identity[key] = value
+		}
+	}
+
+	// The states are only changed when the flags are given
+	var active, enabled *bool
+	if cmd.Flags().Changed("active") {
+		value, err := cmd.Flags().GetBool("active")
+		utils.ExitIfError(err)
+		active = &value
+	}
+	if cmd.Flags().Changed("enabled") {
+		value, err := cmd.Flags().GetBool("enabled")
+		utils.ExitIfError(err)
+		enabled = &value
+	}
+
 	noInput, err := cmd.Flags().GetBool("no-input")
 	utils.ExitIfError(err)
 
@@ -445,6 +559,10 @@ func parseFlagsAndArgs(cmd *cobra.Command, args []string) *editParams {
 		parentEntity: parentEntity,
 		displayName:  displayName,
 		organisation: organisation,
+		attributes:   attributes,
+		identity:     identity,
+		active:       active,
+		enabled:      enabled,
 		noInput:      noInput,
 	}
 }
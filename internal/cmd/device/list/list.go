@@ -14,10 +14,12 @@ import (
 // NewCmdList is the command for listing devices.
 func NewCmdList() *cobra.Command {
 	return &cobra.Command{
-		Use:     "list",
-		Short:   "List lists devices in an organisation",
-		Long:    "See Above",        // TODO: Fix
-		Example: "Should make some", // TODO: Fix
+		Use:   "list",
+		Short: "List lists devices in an organisation",
+		Long:  "See Above", // TODO: Fix
+		Example: `aware device list
+aware device list --enabled=false
+aware device list --active=false --plain --no-headers | aware device activate --force`,
 		Aliases: []string{"lists", "ls"},
 		Run:     List,
 	}
@@ -29,10 +31,12 @@ func List(cmd *cobra.Command, _ []string) {
 }
 
 func loadList(cmd *cobra.Command) {
+	filter := parseFilter(cmd)
+
 	devices, total, err := func() ([]*aware.Device, int, error) {
 		s := utils.ShowLoading("Fetching Devices...")
 		defer s.Stop()
-		resp, err := loadDevices()
+		resp, err := filter.loadDevices()
 		return resp, len(resp), err
 	}()
 	utils.ExitIfError(err)
@@ -62,13 +66,19 @@ func loadList(cmd *cobra.Command) {
 			NoHeaders:  noHeaders,
 			NoTruncate: noTruncate,
 		},
-		Refresh: loadDevices,
+		Refresh: filter.loadDevices,
 	}
 
 	utils.ExitIfError(v.Render())
 }
 
-func loadDevices() ([]*aware.Device, error) {
+// filter is the states devices must have to be listed, nil matches either state.
+type filter struct {
+	active  *bool
+	enabled *bool
+}
+
+func (f filter) loadDevices() ([]*aware.Device, error) {
 	client := aware.NewClient(aware.Config{
 		Server:   viper.GetString("server"),
 		Token:    viper.GetString("token"),
@@ -77,12 +87,24 @@ func loadDevices() ([]*aware.Device, error) {
 	})
 
 	resp, err := client.GetAllDevices(aware.GetAllDevicesOptions{
-		OrganisationID: viper.GetString("organisation"),
+		OrganisationID:  viper.GetString("organisation"),
+		IncludeInactive: f.active != nil && !*f.active,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+
+	out := make([]*aware.Device, 0, len(resp))
+	for _, device := range resp {
+		if f.active != nil && device.IsActive != *f.active {
+			continue
+		}
+		if f.enabled != nil && device.IsEnabled != *f.enabled {
+			continue
+		}
+		out = append(out, device)
+	}
+	return out, nil
 }
 
 // SetFlags sets all the flags for the command.
@@ -90,4 +112,24 @@ func SetFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("plain", false, "Display output in plain mode")
 	cmd.Flags().Bool("no-truncate", false, "Show all available columns in plain mode. Works only with --plain")
 	cmd.Flags().Bool("no-headers", false, "Don't display headers in plain mode. Works only with --plain")
+	cmd.Flags().Bool("active", false, "Only list active devices, or inactive devices with --active=false (default is both)")
+	cmd.Flags().Bool("enabled", false, "Only list enabled devices, or disabled devices with --enabled=false (default is both)")
+}
+
+func parseFilter(cmd *cobra.Command) filter {
+	var f filter
+
+	// Devices in either state are listed unless the flag is given
+	if cmd.Flags().Changed("active") {
+		active, err := cmd.Flags().GetBool("active")
+		utils.ExitIfError(err)
+		f.active = &active
+	}
+	if cmd.Flags().Changed("enabled") {
+		enabled, err := cmd.Flags().GetBool("enabled")
+		utils.ExitIfError(err)
+		f.enabled = &enabled
+	}
+
+	return f
 }
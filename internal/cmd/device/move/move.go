@@ -0,0 +1,247 @@
+// Package move contains the command for moving devices to another entity.
+package move
+
+import (
+	"fmt"
+	"os"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type moveParams struct {
+	IDs            []string
+	to             string
+	entityID       string
+	deviceTypeKind string
+	stdin          bool
+	force          bool
+}
+
+type moveCmd struct {
+	client  *aware.Client
+	params  *moveParams
+	target  *aware.Entity
+	devices []*aware.Device
+}
+
+// NewCmdMove is the command for moving devices to another entity.
+func NewCmdMove() *cobra.Command {
+	return &cobra.Command{
+		Use:   "move [ID...] --to ENTITY",
+		Short: "Move devices to another entity",
+		Long: `Move devices to another parent entity, keeping their other details.
+
+The devices are given by ID, by the --entity and --type filters, or as IDs on stdin
+with one per line. Devices already under the entity are skipped. The move is confirmed
+first unless --force is set, which is required when reading from stdin.`,
+		Example: `aware device move 5d1d574439d157849090ea6a --to 5cf48c71b2f30979bc612292
+aware device move --entity 5cf48c71b2f30979bc612291 --type integrated-protection-relay --to 5cf48c71b2f30979bc612292
+aware device list --plain --no-headers | aware device move --to 5cf48c71b2f30979bc612292 --force`,
+		Aliases: []string{"mv"},
+		Run:     move,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("to", "", "ID of the entity to move the devices to")
+	cmd.Flags().String("entity", "", "Move every device under the given entity")
+	cmd.Flags().String("type", "", "Move every device of the given device type kind")
+	cmd.Flags().BoolP("force", "f", false, "Move the devices without confirming")
+}
+
+func move(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	mc := moveCmd{
+		client: client,
+		params: params,
+	}
+
+	utils.ExitIfError(mc.setTarget())
+	utils.ExitIfError(mc.setDevices())
+
+	if len(mc.devices) == 0 {
+		utils.Warn("No devices to move")
+		return
+	}
+
+	if !mc.params.force {
+		for _, device := range mc.devices {
+			fmt.Printf("%s\t%s\t%s -> %s\n", device.ID, device.DisplayName, device.ParentEntity.GetParentHierachyName(), mc.target.GetParentHierachyName())
+		}
+		fmt.Println()
+
+		var confirm bool
+
+		qs := &survey.Question{
+			Name:     "confirm",
+			Prompt:   &survey.Confirm{Message: fmt.Sprintf("Are you sure you want to move %d devices?", len(mc.devices))},
+			Validate: survey.Required,
+		}
+
+		if err := survey.Ask([]*survey.Question{qs}, &confirm); err != nil {
+			utils.ExitIfError(err)
+		}
+
+		if !confirm {
+			return
+		}
+	}
+
+	var failures []string
+	bar := utils.ShowProgress("Moving", len(mc.devices))
+	for _, device := range mc.devices {
+		if err := mc.move(device.ID); err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%s): %s", device.DisplayName, device.ID, err))
+		}
+		bar.Increment(1)
+	}
+	bar.Stop()
+
+	for _, failure := range failures {
+		utils.Fail("%s", failure)
+	}
+
+	if failed := len(failures); failed > 0 {
+		utils.Failed("Moved %d of %d devices", len(mc.devices)-failed, len(mc.devices))
+	}
+
+	utils.Success("Moved %d devices to %s", len(mc.devices), mc.target.GetParentHierachyName())
+}
+
+// move moves the device to the target. The update replaces every field, so the device
+// is fetched again for the details the list of devices leaves out.
+func (m *moveCmd) move(id string) error {
+	device, err := m.client.GetDeviceByID(id)
+	if err != nil {
+		return err
+	}
+
+	return m.client.UpdateDeviceByID(device.ID, &aware.UpdateDeviceRequest{
+		DeviceType:   device.DeviceType.ID,
+		ParentEntity: m.target.ID,
+		Organisation: device.Organisation,
+		DisplayName:  device.DisplayName,
+		IsActive:     device.IsActive,
+		IsEnabled:    device.IsEnabled,
+		Attributes:   device.Attributes,
+		Identity:     device.Identity,
+		Credentials:  device.Credentials,
+	})
+}
+
+func (m *moveCmd) setTarget() error {
+	s := utils.ShowLoading(fmt.Sprintf("Fetching Entity %s", m.params.to))
+	defer s.Stop()
+
+	target, err := m.client.GetEntityByID(m.params.to)
+	if err != nil {
+		return err
+	}
+
+	m.target = target
+	return nil
+}
+
+func (m *moveCmd) setDevices() error {
+	if m.params.stdin {
+		ids, err := utils.ReadIDs(os.Stdin)
+		if err != nil {
+			return err
+		}
+		m.params.IDs = append(m.params.IDs, ids...)
+	}
+
+	var devices []*aware.Device
+	if len(m.params.IDs) > 0 {
+		s := utils.ShowLoading("Fetching Devices...")
+		defer s.Stop()
+
+		for _, id := range m.params.IDs {
+			device, err := m.client.GetDeviceByID(id)
+			if err != nil {
+				return err
+			}
+			devices = append(devices, device)
+		}
+	} else {
+		s := utils.ShowLoading("Fetching Devices...")
+		defer s.Stop()
+
+		all, err := m.client.GetAllDevices(aware.GetAllDevicesOptions{
+			OrganisationID: viper.GetString("organisation"),
+			DeviceTypeKind: m.params.deviceTypeKind,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, device := range all {
+			if m.params.deviceTypeKind != "" && device.DeviceType.Kind != m.params.deviceTypeKind {
+				continue
+			}
+			if m.params.entityID != "" && !device.ParentEntity.IsUnder(m.params.entityID) {
+				continue
+			}
+			devices = append(devices, device)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, device := range devices {
+		if _, ok := seen[device.ID]; ok || device.ParentEntity.ID == m.target.ID {
+			continue
+		}
+		seen[device.ID] = struct{}{}
+		m.devices = append(m.devices, device)
+	}
+
+	return nil
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *moveParams {
+	to, err := cmd.Flags().GetString("to")
+	utils.ExitIfError(err)
+
+	entityID, err := cmd.Flags().GetString("entity")
+	utils.ExitIfError(err)
+
+	deviceTypeKind, err := cmd.Flags().GetString("type")
+	utils.ExitIfError(err)
+
+	force, err := cmd.Flags().GetBool("force")
+	utils.ExitIfError(err)
+
+	if to == "" {
+		utils.Failed("The entity to move to is required, set it with --to")
+	}
+
+	stdin := utils.StdinHasData()
+	if len(args) == 0 && !stdin && entityID == "" && deviceTypeKind == "" {
+		utils.Failed("Give the devices to move as IDs, with --entity or --type, or on stdin")
+	}
+	if stdin && !force {
+		utils.Failed("--force is required when reading IDs from stdin")
+	}
+
+	return &moveParams{
+		IDs:            args,
+		to:             to,
+		entityID:       entityID,
+		deviceTypeKind: deviceTypeKind,
+		stdin:          stdin,
+		force:          force,
+	}
+}
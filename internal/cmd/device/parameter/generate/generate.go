@@ -0,0 +1,126 @@
+// Package generate contains the command for publishing a single parameter value.
+package generate
+
+import (
+	"fmt"
+	"time"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type generateParams struct {
+	ID        string
+	parameter string
+	value     string
+	hasValue  bool
+	timestamp time.Time
+}
+
+// NewCmdGenerate is the command for publishing a single parameter value.
+func NewCmdGenerate() *cobra.Command {
+	return &cobra.Command{
+		Use:   "generate ID PARAMETER",
+		Short: "Publish a single value for a parameter of a device",
+		Long: `Publish a single value for a parameter of a device.
+
+The parameter is matched by its name or display name. The value given with
+--value is checked against the parameter's value type and range, a display
+value label such as "Running" can be used in place of the value it is shown for.
+Without --value a value is generated the same way as telemetry generate.`,
+		Example: `aware device parameter generate 5d1d574439d157849090ea6a motor-speed
+aware device parameter generate 5d1d574439d157849090ea6a motor-speed --value 1450
+aware device parameter generate 5d1d574439d157849090ea6a "Run State" --value Running --timestamp 2022-10-01T09:00:00Z`,
+		Aliases: []string{"gen", "publish"},
+		Args:    cobra.ExactArgs(2),
+		Run:     generate,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("value", "v", "", "Value to publish, generated when not given")
+	cmd.Flags().String("timestamp", "", "RFC3339 time of the value, defaults to now")
+}
+
+func generate(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	device, err := func() (*aware.Device, error) {
+		s := utils.ShowLoading(fmt.Sprintf("Fetching Device %s", params.ID))
+		defer s.Stop()
+
+		return client.GetDeviceByID(params.ID)
+	}()
+	utils.ExitIfError(err)
+
+	parameter, err := device.DeviceType.GetParameter(params.parameter)
+	utils.ExitIfError(err)
+
+	var value interface{}
+	if params.hasValue {
+		value, err = parameter.ParseValue(params.value)
+		utils.ExitIfError(err)
+	} else {
+		value = parameter.GetRandomValue(params.timestamp)
+		if value == nil {
+			utils.Failed("Unable to generate a %s value for %s, give one with --value", parameter.ValueType, parameter.Name)
+		}
+	}
+
+	err = func() error {
+		s := utils.ShowLoading(fmt.Sprintf("Publishing %s", parameter.Name))
+		defer s.Stop()
+
+		return client.PublishTelemetry(device.ID, parameter.Name, value, params.timestamp)
+	}()
+	utils.ExitIfError(err)
+
+	utils.Success("Published %s %s to %s", parameter.Name, summarise(value), device.DisplayName)
+}
+
+// summarise describes the value in a line, structured values are only described by their type.
+func summarise(value interface{}) string {
+	switch v := value.(type) {
+	case *aware.WaveformValue:
+		return fmt.Sprintf("waveform of %d samples", len(v.Samples))
+	case *aware.SpectrumValue:
+		return fmt.Sprintf("spectrum of %d bins", len(v.Magnitudes))
+	case map[string]interface{}, []interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *generateParams {
+	value, err := cmd.Flags().GetString("value")
+	utils.ExitIfError(err)
+
+	timestampFlag, err := cmd.Flags().GetString("timestamp")
+	utils.ExitIfError(err)
+
+	timestamp := time.Now()
+	if timestampFlag != "" {
+		timestamp, err = time.Parse(time.RFC3339, timestampFlag)
+		if err != nil {
+			utils.Failed("--timestamp must be an RFC3339 time, such as 2022-10-01T09:00:00Z")
+		}
+	}
+
+	return &generateParams{
+		ID:        args[0],
+		parameter: args[1],
+		value:     value,
+		hasValue:  cmd.Flags().Changed("value"),
+		timestamp: timestamp,
+	}
+}
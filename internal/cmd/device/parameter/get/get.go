@@ -0,0 +1,71 @@
+// Package get contains the command for getting the latest value of a parameter.
+package get
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewCmdGet is the command for getting the latest value of a parameter.
+func NewCmdGet() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID PARAMETER",
+		Short: "Get the latest value of a parameter of a device",
+		Long: `Get the latest value of a parameter of a device with its unit and when it was published.
+The parameter is matched by its name or display name.`,
+		Example: `aware device parameter get 5d1d574439d157849090ea6a motor-speed
+aware device parameter get 5d1d574439d157849090ea6a "Run State"`,
+		Aliases: []string{"latest"},
+		Args:    cobra.ExactArgs(2),
+		Run:     get,
+	}
+}
+
+func get(_ *cobra.Command, args []string) {
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	device, err := func() (*aware.Device, error) {
+		s := utils.ShowLoading(fmt.Sprintf("Fetching Device %s", args[0]))
+		defer s.Stop()
+
+		return client.GetDeviceByID(args[0])
+	}()
+	utils.ExitIfError(err)
+
+	parameter, err := device.DeviceType.GetParameter(args[1])
+	utils.ExitIfError(err)
+
+	values, err := func() ([]*aware.TelemetryValue, error) {
+		s := utils.ShowLoading("Fetching Latest Telemetry...")
+		defer s.Stop()
+
+		return client.GetLatestTelemetry(device.ID)
+	}()
+	utils.ExitIfError(err)
+
+	for _, value := range values {
+		if value.ParameterName != parameter.Name {
+			continue
+		}
+
+		v := view.ParameterValue{
+			Device:    device,
+			Parameter: parameter,
+			Value:     value,
+		}
+		utils.ExitIfError(v.Render())
+		return
+	}
+
+	utils.Failed("No value has been published for %s of %s", parameter.Name, device.DisplayName)
+}
@@ -0,0 +1,87 @@
+// Package list contains the command for listing the parameters of a device.
+package list
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type listParams struct {
+	ID        string
+	plain     bool
+	noHeaders bool
+}
+
+// NewCmdList is the command for listing the parameters of a device.
+func NewCmdList() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list ID",
+		Short: "List the parameters of a device",
+		Long:  "List the parameters of a device's type with their value type, unit and range.",
+		Example: `aware device parameter list 5d1d574439d157849090ea6a
+aware device parameter list 5d1d574439d157849090ea6a --plain --no-headers`,
+		Aliases: []string{"lists", "ls"},
+		Args:    cobra.ExactArgs(1),
+		Run:     list,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("plain", false, "Display output in plain mode")
+	cmd.Flags().Bool("no-headers", false, "Don't display headers in plain mode. Works only with --plain")
+}
+
+func list(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	device, err := func() (*aware.Device, error) {
+		s := utils.ShowLoading(fmt.Sprintf("Fetching Device %s", params.ID))
+		defer s.Stop()
+
+		return client.GetDeviceByID(params.ID)
+	}()
+	utils.ExitIfError(err)
+
+	if len(device.DeviceType.Parameters) == 0 {
+		fmt.Println()
+		utils.Failed("Device type %s has no parameters", device.DeviceType.Name)
+		return
+	}
+
+	v := view.ParameterList{
+		Parameters: device.DeviceType.Parameters,
+		Display: view.ParameterDisplayFormat{
+			Plain:     params.plain,
+			NoHeaders: params.noHeaders,
+		},
+	}
+
+	utils.ExitIfError(v.Render())
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *listParams {
+	plain, err := cmd.Flags().GetBool("plain")
+	utils.ExitIfError(err)
+
+	noHeaders, err := cmd.Flags().GetBool("no-headers")
+	utils.ExitIfError(err)
+
+	return &listParams{
+		ID:        args[0],
+		plain:     plain,
+		noHeaders: noHeaders,
+	}
+}
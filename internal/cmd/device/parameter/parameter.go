@@ -0,0 +1,40 @@
+// Package parameter contains the root command for device parameters.
+package parameter
+
+import (
+	"ampaware.com/cli/internal/cmd/device/parameter/generate"
+	"ampaware.com/cli/internal/cmd/device/parameter/get"
+	"ampaware.com/cli/internal/cmd/device/parameter/list"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdDeviceParameter is the root command for device parameters.
+func NewCmdDeviceParameter() *cobra.Command {
+	cmd := cobra.Command{
+		Use:         "parameter",
+		Short:       "Manage the Parameters of a Device",
+		Long:        "List the parameters of a device, publish a single value or get its latest value.",
+		Aliases:     []string{"parameters", "param"},
+		Annotations: map[string]string{},
+		RunE:        parameter,
+	}
+
+	lc := list.NewCmdList()
+	gen := generate.NewCmdGenerate()
+	ge := get.NewCmdGet()
+
+	cmd.AddCommand(
+		lc,
+		gen,
+		ge,
+	)
+
+	list.SetFlags(lc)
+	generate.SetFlags(gen)
+
+	return &cmd
+}
+
+func parameter(cmd *cobra.Command, _ []string) error {
+	return cmd.Help()
+}
@@ -0,0 +1,109 @@
+// Package show contains the command for viewing a device.
+package show
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type showCmd struct {
+	client  *aware.Client
+	ID      string
+	devices []*aware.Device
+}
+
+// NewCmdView is the command for viewing a device.
+func NewCmdView() *cobra.Command {
+	return &cobra.Command{
+		Use:   "view [ID]",
+		Short: "View a device",
+		Long: `View the details of a device with its attributes and identity.
+If no ID is given the device can be picked from a list.`,
+		Example: "aware device view 5d1d574439d157849090ea6a",
+		Aliases: []string{"show", "get"},
+		Args:    cobra.MaximumNArgs(1),
+		Run:     show,
+	}
+}
+
+func show(cmd *cobra.Command, args []string) {
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	sc := showCmd{
+		client: client,
+	}
+	if len(args) >= 1 {
+		sc.ID = args[0]
+	} else {
+		utils.ExitIfError(sc.setDevices())
+		utils.ExitIfError(sc.getDevice())
+	}
+
+	device, err := func() (*aware.Device, error) {
+		s := utils.ShowLoading(fmt.Sprintf("Fetching Device %s", sc.ID))
+		defer s.Stop()
+
+		return client.GetDeviceByID(sc.ID)
+	}()
+	utils.ExitIfError(err)
+
+	v := view.DeviceDetail{Device: device}
+	utils.ExitIfError(v.Render())
+}
+
+func (s *showCmd) setDevices() error {
+	sp := utils.ShowLoading("Fetching Devices...")
+	defer sp.Stop()
+
+	devices, err := s.client.GetAllDevices(aware.GetAllDevicesOptions{
+		OrganisationID: viper.GetString("organisation"),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.devices = devices
+	return nil
+}
+
+func (s *showCmd) getDevice() error {
+	var ans string
+
+	options := make([]string, 0, len(s.devices))
+	for _, device := range s.devices {
+		options = append(options, device.ID+" - "+device.ParentEntity.GetParentHierachyName()+" - "+device.DisplayName)
+	}
+
+	qs := &survey.Question{
+		Name: "id",
+		Prompt: &survey.Select{
+			Message: "Device:",
+			Options: options,
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return err
+	}
+
+	for i, device := range s.devices {
+		if ans == options[i] {
+			s.ID = device.ID
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,278 @@
+// Package state contains the commands for enabling, disabling, activating and deactivating devices.
+package state
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// change is a state to set on devices.
+type change struct {
+	name    string // Such as enable
+	title   string // Such as Enable
+	doing   string // Such as Enabling
+	done    string // Such as Enabled
+	enabled bool   // Whether the enabled state is set, otherwise the active state is
+	value   bool
+}
+
+type stateParams struct {
+	IDs            []string
+	entityID       string
+	deviceTypeKind string
+	stdin          bool
+	force          bool
+}
+
+type stateCmd struct {
+	client  *aware.Client
+	params  *stateParams
+	change  change
+	devices []*aware.Device
+}
+
+// NewCmdEnable is the command for enabling devices.
+func NewCmdEnable() *cobra.Command {
+	return newCmd(change{name: "enable", title: "Enable", doing: "Enabling", done: "Enabled", enabled: true, value: true})
+}
+
+// NewCmdDisable is the command for disabling devices.
+func NewCmdDisable() *cobra.Command {
+	return newCmd(change{name: "disable", title: "Disable", doing: "Disabling", done: "Disabled", enabled: true, value: false})
+}
+
+// NewCmdActivate is the command for activating devices.
+func NewCmdActivate() *cobra.Command {
+	return newCmd(change{name: "activate", title: "Activate", doing: "Activating", done: "Activated", enabled: false, value: true})
+}
+
+// NewCmdDeactivate is the command for deactivating devices.
+func NewCmdDeactivate() *cobra.Command {
+	return newCmd(change{name: "deactivate", title: "Deactivate", doing: "Deactivating", done: "Deactivated", enabled: false, value: false})
+}
+
+func newCmd(c change) *cobra.Command {
+	return &cobra.Command{
+		Use:   c.name + " [ID...]",
+		Short: c.title + " devices",
+		Long: fmt.Sprintf(`%s devices, keeping their other details.
+
+The devices are given by ID, by the --entity and --type filters, or as IDs on stdin
+with one per line. Devices that are already %s are skipped. The change is confirmed
+first unless --force is set, which is required when reading from stdin.`, c.title, strings.ToLower(c.done)),
+		Example: fmt.Sprintf(`aware device %[1]s 5d1d574439d157849090ea6a
+aware device %[1]s --entity 5cf48c71b2f30979bc612292 --type integrated-protection-relay
+aware device list --plain --no-headers | aware device %[1]s --force`, c.name),
+		Run: func(cmd *cobra.Command, args []string) {
+			run(cmd, args, c)
+		},
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("entity", "", "Change every device under the given entity")
+	cmd.Flags().String("type", "", "Change every device of the given device type kind")
+	cmd.Flags().BoolP("force", "f", false, "Change the devices without confirming")
+}
+
+func run(cmd *cobra.Command, args []string, c change) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	sc := stateCmd{
+		client: client,
+		params: params,
+		change: c,
+	}
+
+	utils.ExitIfError(sc.setDevices())
+
+	if len(sc.devices) == 0 {
+		utils.Warn("No devices to %s", c.name)
+		return
+	}
+
+	if !sc.params.force {
+		for _, device := range sc.devices {
+			fmt.Printf("%s\t%s\t%s\n", device.ID, device.DisplayName, device.ParentEntity.GetParentHierachyName())
+		}
+		fmt.Println()
+
+		var confirm bool
+
+		qs := &survey.Question{
+			Name:     "confirm",
+			Prompt:   &survey.Confirm{Message: fmt.Sprintf("Are you sure you want to %s %d devices?", c.name, len(sc.devices))},
+			Validate: survey.Required,
+		}
+
+		if err := survey.Ask([]*survey.Question{qs}, &confirm); err != nil {
+			utils.ExitIfError(err)
+		}
+
+		if !confirm {
+			return
+		}
+	}
+
+	var failures []string
+	bar := utils.ShowProgress(c.doing, len(sc.devices))
+	for _, device := range sc.devices {
+		if err := sc.update(device.ID); err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%s): %s", device.DisplayName, device.ID, err))
+		}
+		bar.Increment(1)
+	}
+	bar.Stop()
+
+	for _, failure := range failures {
+		utils.Fail("%s", failure)
+	}
+
+	if failed := len(failures); failed > 0 {
+		utils.Failed("%s %d of %d devices", c.done, len(sc.devices)-failed, len(sc.devices))
+	}
+
+	utils.Success("%s %d devices", c.done, len(sc.devices))
+}
+
+// update sets the state on the device. The update replaces every field, so the device
+// is fetched again for the details the list of devices leaves out.
+func (s *stateCmd) update(id string) error {
+	device, err := s.client.GetDeviceByID(id)
+	if err != nil {
+		return err
+	}
+
+	return s.client.UpdateDeviceByID(device.ID, s.change.request(device))
+}
+
+// current returns the state of the device the change sets.
+func (c change) current(device *aware.Device) bool {
+	if c.enabled {
+		return device.IsEnabled
+	}
+	return device.IsActive
+}
+
+// request returns the update for the device with the state changed.
+func (c change) request(device *aware.Device) *aware.UpdateDeviceRequest {
+	// The update replaces every field so the unchanged ones are sent as they are
+	req := &aware.UpdateDeviceRequest{
+		DeviceType:   device.DeviceType.ID,
+		ParentEntity: device.ParentEntity.ID,
+		Organisation: device.Organisation,
+		DisplayName:  device.DisplayName,
+		IsActive:     device.IsActive,
+		IsEnabled:    device.IsEnabled,
+		Attributes:   device.Attributes,
+		Identity:     device.Identity,
+		Credentials:  device.Credentials,
+	}
+	if c.enabled {
+		req.IsEnabled = c.value
+	} else {
+		req.IsActive = c.value
+	}
+
+	return req
+}
+
+func (s *stateCmd) setDevices() error {
+	if s.params.stdin {
+		ids, err := utils.ReadIDs(os.Stdin)
+		if err != nil {
+			return err
+		}
+		s.params.IDs = append(s.params.IDs, ids...)
+	}
+
+	var devices []*aware.Device
+	if len(s.params.IDs) > 0 {
+		sp := utils.ShowLoading("Fetching Devices...")
+		defer sp.Stop()
+
+		for _, id := range s.params.IDs {
+			device, err := s.client.GetDeviceByID(id)
+			if err != nil {
+				return err
+			}
+			devices = append(devices, device)
+		}
+	} else {
+		sp := utils.ShowLoading("Fetching Devices...")
+		defer sp.Stop()
+
+		// Inactive devices are included so they can be activated
+		all, err := s.client.GetAllDevices(aware.GetAllDevicesOptions{
+			OrganisationID:  viper.GetString("organisation"),
+			DeviceTypeKind:  s.params.deviceTypeKind,
+			IncludeInactive: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, device := range all {
+			if s.params.deviceTypeKind != "" && device.DeviceType.Kind != s.params.deviceTypeKind {
+				continue
+			}
+			if s.params.entityID != "" && !device.ParentEntity.IsUnder(s.params.entityID) {
+				continue
+			}
+			devices = append(devices, device)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, device := range devices {
+		if _, ok := seen[device.ID]; ok || s.change.current(device) == s.change.value {
+			continue
+		}
+		seen[device.ID] = struct{}{}
+		s.devices = append(s.devices, device)
+	}
+
+	return nil
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *stateParams {
+	entityID, err := cmd.Flags().GetString("entity")
+	utils.ExitIfError(err)
+
+	deviceTypeKind, err := cmd.Flags().GetString("type")
+	utils.ExitIfError(err)
+
+	force, err := cmd.Flags().GetBool("force")
+	utils.ExitIfError(err)
+
+	stdin := utils.StdinHasData()
+	if len(args) == 0 && !stdin && entityID == "" && deviceTypeKind == "" {
+		utils.Failed("Give the devices as IDs, with --entity or --type, or on stdin")
+	}
+	if stdin && !force {
+		utils.Failed("--force is required when reading IDs from stdin")
+	}
+
+	return &stateParams{
+		IDs:            args,
+		entityID:       entityID,
+		deviceTypeKind: deviceTypeKind,
+		stdin:          stdin,
+		force:          force,
+	}
+}
@@ -0,0 +1,44 @@
+package state
+
+import (
+	"testing"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+func TestChangeRequest(t *testing.T) {
+	is := is.New(t)
+
+	device := &aware.Device{
+		ID:           "DEV-1",
+		DisplayName:  "Pump 1",
+		DeviceType:   aware.DeviceType{ID: "TYPE-1"},
+		ParentEntity: aware.Entity{ID: "LINE"},
+		Organisation: "ORG",
+		IsActive:     true,
+		IsEnabled:    true,
+		Attributes:   []aware.DeviceAttribute{{Name: "serial-number", Value: "SN-1"}},
+		Identity:     map[string]interface{}{"mac": "00:11"},
+	}
+
+	disable := NewCmdDisable()
+	is.Equal(disable.Use, "disable [ID...]")
+
+	c := change{name: "disable", enabled: true, value: false}
+	is.True(c.current(device)) // Enabled, so it will be changed
+
+	req := c.request(device)
+	is.Equal(req.IsEnabled, false)
+	is.Equal(req.IsActive, true) // Only the state being changed is
+	is.Equal(req.DeviceType, "TYPE-1")
+	is.Equal(req.ParentEntity, "LINE")
+	is.Equal(req.DisplayName, "Pump 1")
+	is.Equal(req.Attributes, device.Attributes)
+	is.Equal(req.Identity, device.Identity)
+
+	c = change{name: "deactivate", enabled: false, value: false}
+	req = c.request(device)
+	is.Equal(req.IsActive, false)
+	is.Equal(req.IsEnabled, true)
+}
@@ -0,0 +1,330 @@
+// Package backfill contains the command for generating historical device telemetry.
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const dateLayout = "2006-01-02"
+
+type backfillParams struct {
+	ID          string
+	from        time.Time
+	to          time.Time
+	interval    time.Duration
+	concurrency int
+	dryRun      bool
+	restart     bool
+}
+
+type backfillCmd struct {
+	client     *aware.Client
+	params     *backfillParams
+	device     *aware.Device
+	timestamps []time.Time
+	progress   *checkpoint
+	failed     int
+	lastErr    error // The last error publishing an interval, shown when any failed
+}
+
+// NewCmdDeviceTelemetryBackfill is the command for backfilling device telemetry.
+func NewCmdDeviceTelemetryBackfill() *cobra.Command {
+	return &cobra.Command{
+		Use:   "backfill ID",
+		Short: "Generate historical telemetry for a device",
+		Long: `Generate telemetry for every interval between two points in time.
+
+Progress is saved as values are published, if the backfill is interrupted
+running the same command again will resume where it left off.`,
+		Example: "aware device telemetry backfill 5d1d574439d157849090ea6a --from 2026-01-01 --to 2026-02-01 --interval 5m",
+		Args:    cobra.ExactArgs(1),
+		Run:     backfill,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("from", "", "Start of the range, as a date (2006-01-02) or RFC3339 timestamp")
+	cmd.Flags().String("to", "", "End of the range (exclusive), as a date (2006-01-02) or RFC3339 timestamp")
+	cmd.Flags().Duration("interval", 5*time.Minute, "Time between generated values")
+	cmd.Flags().Int("concurrency", 4, "Maximum number of intervals to publish at once")
+	cmd.Flags().Bool("dry-run", false, "Show what would be published without publishing")
+	cmd.Flags().Bool("restart", false, "Ignore any saved progress and start from the beginning")
+
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+}
+
+func backfill(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	bc := backfillCmd{
+		client:     client,
+		params:     params,
+		timestamps: timestampsBetween(params.from, params.to, params.interval),
+	}
+
+	if len(bc.timestamps) == 0 {
+		utils.Failed("No intervals between %s and %s", params.from.Format(time.RFC3339), params.to.Format(time.RFC3339))
+	}
+
+	utils.ExitIfError(bc.setDevice())
+
+	if params.dryRun {
+		bc.printPlan()
+		return
+	}
+
+	utils.ExitIfError(bc.loadCheckpoint())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+	go func() {
+		select {
+		case <-signalChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	bc.run(ctx)
+	utils.ExitIfError(bc.progress.save())
+
+	if ctx.Err() != nil {
+		fmt.Println()
+		utils.Warn("Backfill interrupted, run the same command again to resume.")
+		os.Exit(1)
+	}
+
+	if bc.failed > 0 {
+		utils.Fail("Last error: %s", bc.lastErr)
+		utils.Failed("%d of %d intervals failed to publish, run the same command again to retry them.", bc.failed, len(bc.timestamps))
+	}
+
+	utils.ExitIfError(bc.progress.remove())
+	utils.Success("Backfilled %d intervals for %s", len(bc.timestamps), bc.device.DisplayName)
+}
+
+func (b *backfillCmd) setDevice() error {
+	s := utils.ShowLoading(fmt.Sprintf("Fetching Device %s", b.params.ID))
+	defer s.Stop()
+
+	device, err := b.client.GetDeviceByID(b.params.ID)
+	if err != nil {
+		return err
+	}
+
+	b.device = device
+	return nil
+}
+
+func (b *backfillCmd) loadCheckpoint() error {
+	cp, err := loadCheckpoint(b.params)
+	if err != nil {
+		return err
+	}
+
+	if b.params.restart {
+		cp.reset()
+	} else if done := cp.count(); done > 0 && cp.Next < len(b.timestamps) {
+		utils.Warn("Resuming backfill from %s (%d/%d complete)", b.timestamps[cp.Next].Format(time.RFC3339), done, len(b.timestamps))
+	}
+
+	b.progress = cp
+	return nil
+}
+
+func (b *backfillCmd) printPlan() {
+	parameters := len(b.device.DeviceType.Parameters)
+
+	fmt.Printf("Device:     %s (%s)\n", b.device.DisplayName, b.device.ID)
+	fmt.Printf("Range:      %s -> %s\n", b.params.from.Format(time.RFC3339), b.params.to.Format(time.RFC3339))
+	fmt.Printf("Interval:   %s\n", b.params.interval)
+	fmt.Printf("Intervals:  %d\n", len(b.timestamps))
+	fmt.Printf("Parameters: %d\n", parameters)
+	fmt.Printf("Values:     %d\n", len(b.timestamps)*parameters)
+	fmt.Println()
+
+	const preview = 3
+	for i, ts := range b.timestamps {
+		if i == preview && len(b.timestamps) > preview*2 {
+			fmt.Println("...")
+			continue
+		}
+		if i > preview && i < len(b.timestamps)-preview {
+			continue
+		}
+		fmt.Println(ts.Format(time.RFC3339))
+	}
+}
+
+func (b *backfillCmd) run(ctx context.Context) {
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	bar := utils.ShowProgress("Backfilling", len(b.timestamps))
+	bar.Set(b.progress.count())
+	defer bar.Stop()
+
+	const saveEvery = 50
+
+	work := make(chan int)
+	for w := 0; w < b.params.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				if err := b.publish(ctx, i); err != nil {
+					// Intervals cut short by an interrupt are resumed rather than failed
+					if ctx.Err() != nil {
+						continue
+					}
+					mu.Lock()
+					b.failed++
+					b.lastErr = err
+					mu.Unlock()
+					continue
+				}
+
+				if b.progress.complete(i)%saveEvery == 0 {
+					_ = b.progress.save()
+				}
+				bar.Increment(1)
+			}
+		}()
+	}
+
+	for i := range b.timestamps {
+		if b.progress.isComplete(i) {
+			continue
+		}
+
+		select {
+		case work <- i:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	close(work)
+	wg.Wait()
+}
+
+// publish publishes every parameter for the interval, skipping those already
+// published by an earlier attempt.
+func (b *backfillCmd) publish(ctx context.Context, i int) error {
+	for _, parameter := range b.device.DeviceType.Parameters {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if b.progress.isPublished(i, parameter.Name) {
+			continue
+		}
+
+		ts := b.timestamps[i]
+		err := b.client.PublishTelemetry(b.device.ID, parameter.Name, parameter.GetRandomValue(ts), ts)
+		if err != nil {
+			return err
+		}
+		b.progress.published(i, parameter.Name)
+	}
+
+	return nil
+}
+
+func timestampsBetween(from, to time.Time, interval time.Duration) []time.Time {
+	var out []time.Time
+
+	if interval <= 0 {
+		return out
+	}
+
+	for ts := from; ts.Before(to); ts = ts.Add(interval) {
+		out = append(out, ts)
+	}
+
+	return out
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation(dateLayout, value, time.Local); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *backfillParams {
+	fromFlag, err := cmd.Flags().GetString("from")
+	utils.ExitIfError(err)
+
+	from, err := parseTime(fromFlag)
+	utils.ExitIfError(err)
+
+	toFlag, err := cmd.Flags().GetString("to")
+	utils.ExitIfError(err)
+
+	to, err := parseTime(toFlag)
+	utils.ExitIfError(err)
+
+	if !from.Before(to) {
+		utils.Failed("--from must be before --to")
+	}
+
+	interval, err := cmd.Flags().GetDuration("interval")
+	utils.ExitIfError(err)
+
+	if interval <= 0 {
+		utils.Failed("--interval must be greater than zero")
+	}
+
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	utils.ExitIfError(err)
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	utils.ExitIfError(err)
+
+	restart, err := cmd.Flags().GetBool("restart")
+	utils.ExitIfError(err)
+
+	return &backfillParams{
+		ID:          args[0],
+		from:        from,
+		to:          to,
+		interval:    interval,
+		concurrency: concurrency,
+		dryRun:      dryRun,
+		restart:     restart,
+	}
+}
@@ -0,0 +1,106 @@
+package backfill
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+func TestBackfillRunResumesPartialInterval(t *testing.T) {
+	is := is.New(t)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var (
+		mu        sync.Mutex
+		published = make(map[string]int)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Timestamp string `json:"timestamp"`
+			Parameter string `json:"parameter"`
+		}
+		is.NoErr(json.NewDecoder(r.Body).Decode(&body))
+
+		mu.Lock()
+		published[body.Timestamp+" "+body.Parameter]++
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := &backfillParams{ID: "DEV-1", from: from, to: from.Add(3 * time.Hour), interval: time.Hour, concurrency: 2}
+
+	// The first interval is complete and the second was interrupted after publishing voltage
+	cp, err := loadCheckpoint(params)
+	is.NoErr(err)
+	cp.complete(0)
+	cp.published(1, "voltage")
+
+	b := backfillCmd{
+		client:     aware.NewClient(aware.Config{Server: server.URL}),
+		params:     params,
+		timestamps: timestampsBetween(params.from, params.to, params.interval),
+		progress:   cp,
+		device: &aware.Device{
+			ID: "DEV-1",
+			DeviceType: aware.DeviceType{Parameters: []aware.DeviceTypeParameter{
+				{Name: "voltage", ValueType: aware.Float},
+				{Name: "current", ValueType: aware.Float},
+			}},
+		},
+	}
+
+	b.run(context.Background())
+
+	is.Equal(b.failed, 0)
+	is.Equal(cp.count(), 3)
+	is.Equal(published, map[string]int{
+		"2026-01-01T01:00:00Z current": 1,
+		"2026-01-01T02:00:00Z voltage": 1,
+		"2026-01-01T02:00:00Z current": 1,
+	})
+}
+
+func TestBackfillRunKeepsLastError(t *testing.T) {
+	is := is.New(t)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := &backfillParams{ID: "DEV-1", from: from, to: from.Add(2 * time.Hour), interval: time.Hour, concurrency: 1}
+
+	cp, err := loadCheckpoint(params)
+	is.NoErr(err)
+
+	b := backfillCmd{
+		client:     aware.NewClient(aware.Config{Server: server.URL}),
+		params:     params,
+		timestamps: timestampsBetween(params.from, params.to, params.interval),
+		progress:   cp,
+		device: &aware.Device{
+			ID:         "DEV-1",
+			DeviceType: aware.DeviceType{Parameters: []aware.DeviceTypeParameter{{Name: "voltage", ValueType: aware.Float}}},
+		},
+	}
+
+	b.run(context.Background())
+
+	is.Equal(b.failed, 2)
+	is.Equal(cp.count(), 0)
+	is.Equal(b.lastErr, &aware.ErrUnexpectedResponse{StatusCode: 503, Status: "503 Service Unavailable"})
+}
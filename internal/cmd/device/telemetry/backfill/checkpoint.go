@@ -0,0 +1,191 @@
+package backfill
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	awareConfig "ampaware.com/cli/internal/config"
+)
+
+// checkpoint records which intervals of a backfill have been published so an
+// interrupted backfill can be resumed. The parameters published for intervals
+// that are only partly complete are recorded so they aren't published again.
+type checkpoint struct {
+	mu      sync.Mutex
+	file    string
+	done    map[int]struct{}
+	partial map[int]map[string]struct{}
+
+	DeviceID  string           `json:"device"`
+	From      time.Time        `json:"from"`
+	To        time.Time        `json:"to"`
+	Interval  string           `json:"interval"`
+	Next      int              `json:"next"`              // Every interval before Next is complete
+	Completed []int            `json:"completed"`         // Intervals after Next that are complete
+	Partial   map[int][]string `json:"partial,omitempty"` // Parameters published for incomplete intervals
+}
+
+func loadCheckpoint(params *backfillParams) (*checkpoint, error) {
+	configDir, err := awareConfig.GetConfigDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	cp := &checkpoint{
+		file:     path.Join(configDir, "backfill", params.ID+".json"),
+		done:     make(map[int]struct{}),
+		partial:  make(map[int]map[string]struct{}),
+		DeviceID: params.ID,
+		From:     params.from,
+		To:       params.to,
+		Interval: params.interval.String(),
+	}
+
+	data, err := os.ReadFile(cp.file)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var saved checkpoint
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("unable to read backfill progress %s: %w", cp.file, err)
+	}
+
+	// Progress from a different range can't be resumed
+	if !saved.From.Equal(cp.From) || !saved.To.Equal(cp.To) || saved.Interval != cp.Interval {
+		return cp, nil
+	}
+
+	cp.Next = saved.Next
+	for _, i := range saved.Completed {
+		cp.done[i] = struct{}{}
+	}
+	for i, parameters := range saved.Partial {
+		for _, parameter := range parameters {
+			cp.published(i, parameter)
+		}
+	}
+
+	return cp, nil
+}
+
+func (c *checkpoint) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Next = 0
+	c.done = make(map[int]struct{})
+	c.partial = make(map[int]map[string]struct{})
+}
+
+// isPublished returns whether the parameter has been published for the interval.
+func (c *checkpoint) isPublished(i int, parameter string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.partial[i][parameter]
+	return ok
+}
+
+// published records the parameter as published for the interval.
+func (c *checkpoint) published(i int, parameter string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.partial[i] == nil {
+		c.partial[i] = make(map[string]struct{})
+	}
+	c.partial[i][parameter] = struct{}{}
+}
+
+func (c *checkpoint) isComplete(i int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if i < c.Next {
+		return true
+	}
+	_, ok := c.done[i]
+	return ok
+}
+
+// complete marks the interval as complete and returns the number of completed intervals.
+func (c *checkpoint) complete(i int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.done[i] = struct{}{}
+	delete(c.partial, i)
+	for {
+		if _, ok := c.done[c.Next]; !ok {
+			break
+		}
+		delete(c.done, c.Next)
+		c.Next++
+	}
+
+	return c.Next + len(c.done)
+}
+
+func (c *checkpoint) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.Next + len(c.done)
+}
+
+func (c *checkpoint) save() error {
+	const (
+		dirPerm  = 0o700
+		filePerm = 0o600
+	)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Completed = make([]int, 0, len(c.done))
+	for i := range c.done {
+		c.Completed = append(c.Completed, i)
+	}
+	sort.Ints(c.Completed)
+
+	c.Partial = make(map[int][]string, len(c.partial))
+	for i, parameters := range c.partial {
+		for parameter := range parameters {
+			c.Partial[i] = append(c.Partial[i], parameter)
+		}
+		sort.Strings(c.Partial[i])
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(path.Dir(c.file), dirPerm); err != nil {
+		return err
+	}
+
+	// Write then rename so an interrupted save doesn't corrupt the progress
+	tmp := c.file + ".tmp"
+	if err := os.WriteFile(tmp, data, filePerm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, c.file)
+}
+
+func (c *checkpoint) remove() error {
+	if err := os.Remove(c.file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
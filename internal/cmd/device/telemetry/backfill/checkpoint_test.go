@@ -0,0 +1,77 @@
+package backfill
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	is := is.New(t)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := &backfillParams{ID: "DEV-1", from: from, to: from.Add(time.Hour), interval: 5 * time.Minute}
+
+	saved, err := loadCheckpoint(params)
+	is.NoErr(err)
+	is.Equal(saved.count(), 0) // Nothing saved yet
+
+	saved.complete(0)
+	saved.complete(1)
+	saved.complete(3)
+	saved.published(2, "voltage")
+	is.NoErr(saved.save())
+
+	info, err := os.Stat(saved.file)
+	is.NoErr(err)
+	is.Equal(info.Mode().Perm(), os.FileMode(0o600))
+
+	tests := []struct {
+		name     string
+		params   backfillParams
+		resumed  bool
+		complete []int
+	}{
+		{"same range", *params, true, []int{0, 1, 3}},
+		{"different interval", backfillParams{ID: "DEV-1", from: from, to: params.to, interval: time.Minute}, false, nil},
+		{"different start", backfillParams{ID: "DEV-1", from: from.Add(time.Minute), to: params.to, interval: params.interval}, false, nil},
+		{"different end", backfillParams{ID: "DEV-1", from: from, to: params.to.Add(time.Hour), interval: params.interval}, false, nil},
+		{"other device", backfillParams{ID: "DEV-2", from: from, to: params.to, interval: params.interval}, false, nil},
+	}
+
+	for _, tt := range tests {
+		cp, err := loadCheckpoint(&tt.params)
+		is.NoErr(err) // tt.name
+
+		for i := 0; i < 5; i++ {
+			is.Equal(cp.isComplete(i), contains(tt.complete, i)) // tt.name
+		}
+		is.Equal(cp.isPublished(2, "voltage"), tt.resumed) // tt.name
+		is.Equal(cp.isPublished(2, "current"), false)      // tt.name
+	}
+
+	// Completing the partial interval forgets its parameters, and the progress is gone once removed
+	cp, err := loadCheckpoint(params)
+	is.NoErr(err)
+	is.Equal(cp.complete(2), 4)
+	is.Equal(cp.Next, 4)
+	is.True(!cp.isPublished(2, "voltage"))
+	is.NoErr(cp.remove())
+
+	cp, err = loadCheckpoint(params)
+	is.NoErr(err)
+	is.Equal(cp.count(), 0)
+}
+
+func contains(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package generate
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/table"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+const (
+	// anomalyFactor is how many times larger than normal an anomalous number is.
+	anomalyFactor = 10
+
+	minFrequency = 100 * time.Millisecond
+	maxFrequency = 24 * time.Hour
+)
+
+// controls is the state of the generator that can be changed while it is running.
+type controls struct {
+	paused    bool
+	disabled  map[string]struct{} // Display names of parameters not being published
+	anomalies map[string]struct{} // IDs of devices whose next values are anomalous
+}
+
+// togglePause pauses or resumes publishing on the schedules.
+func (g *generator) togglePause() {
+	g.ctl.Lock()
+	defer g.ctl.Unlock()
+
+	g.controls.paused = !g.controls.paused
+}
+
+func (g *generator) isPaused() bool {
+	g.ctl.Lock()
+	defer g.ctl.Unlock()
+
+	return g.controls.paused
+}
+
+// publishNow publishes the enabled parameters of every device once, even when paused.
+func (g *generator) publishNow() {
+	select {
+	case g.now <- struct{}{}:
+	default:
+	}
+}
+
+// scaleFrequency multiplies the fallback frequency by the factor, parameters with
+// their own schedule are not changed. A fallback cron schedule has no frequency to scale.
+func (g *generator) scaleFrequency(factor float64) {
+	g.ctl.Lock()
+	current, ok := g.schedules.fallback.schedule.(interval)
+	if !ok {
+		g.ctl.Unlock()
+		return
+	}
+
+	frequency := time.Duration(float64(time.Duration(current)) * factor)
+	if frequency < minFrequency {
+		frequency = minFrequency
+	}
+	if frequency > maxFrequency {
+		frequency = maxFrequency
+	}
+	g.schedules.fallback.spec = frequency.String()
+	g.schedules.fallback.schedule = interval(frequency)
+	g.ctl.Unlock()
+
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+}
+
+// fallback returns the timing of parameters without their own schedule.
+func (g *generator) fallback() timing {
+	g.ctl.Lock()
+	defer g.ctl.Unlock()
+
+	return g.schedules.fallback
+}
+
+// toggleParameter stops or starts publishing the parameter with the display name for every device.
+func (g *generator) toggleParameter(displayName string) {
+	g.ctl.Lock()
+	defer g.ctl.Unlock()
+
+	if _, ok := g.controls.disabled[displayName]; ok {
+		delete(g.controls.disabled, displayName)
+	} else {
+		g.controls.disabled[displayName] = struct{}{}
+	}
+}
+
+// enabled returns the parameters that are being published, in the same order.
+func (g *generator) enabled(device *aware.Device, parameters []int) []int {
+	g.ctl.Lock()
+	defer g.ctl.Unlock()
+
+	out := make([]int, 0, len(parameters))
+	for _, i := range parameters {
+		if _, ok := g.controls.disabled[device.DeviceType.Parameters[i].DisplayName]; !ok {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// injectAnomaly makes the next values published for every device anomalous.
+func (g *generator) injectAnomaly() {
+	g.ctl.Lock()
+	defer g.ctl.Unlock()
+
+	for _, device := range g.devices {
+		g.controls.anomalies[device.ID] = struct{}{}
+	}
+}
+
+// takeAnomaly returns whether the next values of the device should be anomalous,
+// clearing it so only one set of values is.
+func (g *generator) takeAnomaly(device *aware.Device) bool {
+	g.ctl.Lock()
+	defer g.ctl.Unlock()
+
+	_, ok := g.controls.anomalies[device.ID]
+	delete(g.controls.anomalies, device.ID)
+	return ok
+}
+
+// anomalous returns an unexpected value in place of the given one. Numbers are
+// made much larger and booleans are flipped, other values can't be anomalous.
+func anomalous(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if v == 0 {
+			return float64(anomalyFactor)
+		}
+		return v * anomalyFactor
+	case int:
+		if v == 0 {
+			return anomalyFactor
+		}
+		return v * anomalyFactor
+	case bool:
+		return !v
+	}
+	return v
+}
+
+// controlsStatus describes the state of the controls for display.
+func (g *generator) controlsStatus() string {
+	g.ctl.Lock()
+	defer g.ctl.Unlock()
+
+	state := "publishing every " + g.schedules.fallback.spec
+	if g.controls.paused {
+		state = "paused, every " + g.schedules.fallback.spec
+	}
+
+	if len(g.controls.disabled) > 0 {
+		var off []string
+		for _, name := range g.parameterNames {
+			if _, ok := g.controls.disabled[name]; ok {
+				off = append(off, name)
+			}
+		}
+		state += ", off: " + strings.Join(off, ", ")
+	}
+
+	if len(g.controls.anomalies) > 0 {
+		state += ", anomaly pending"
+	}
+
+	return state
+}
+
+// actions are the key bindings for controlling the generator from the table.
+func (g *generator) actions() []table.Action {
+	actions := []table.Action{
+		{
+			Binding: key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pause/resume")),
+			Run:     func(string) { g.togglePause() },
+		},
+		{
+			Binding: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "publish now")),
+			Run:     func(string) { g.publishNow() },
+		},
+		{
+			Binding: key.NewBinding(key.WithKeys("+", "="), key.WithHelp("+", "halve interval")),
+			Run:     func(string) { g.scaleFrequency(0.5) },
+		},
+		{
+			Binding: key.NewBinding(key.WithKeys("-", "_"), key.WithHelp("-", "double interval")),
+			Run:     func(string) { g.scaleFrequency(2) },
+		},
+		{
+			Binding: key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "inject anomaly")),
+			Run:     func(string) { g.injectAnomaly() },
+		},
+	}
+
+	// The first nine parameters are toggled by their position in the table
+	count := len(g.parameterNames)
+	if count > 9 {
+		count = 9
+	}
+	if count > 0 {
+		keys := make([]string, 0, count)
+		for i := 1; i <= count; i++ {
+			keys = append(keys, strconv.Itoa(i))
+		}
+
+		actions = append(actions, table.Action{
+			Binding: key.NewBinding(key.WithKeys(keys...), key.WithHelp("1-"+strconv.Itoa(count), "toggle parameter")),
+			Run: func(k string) {
+				i, err := strconv.Atoi(k)
+				if err == nil && i >= 1 && i <= count {
+					g.toggleParameter(g.parameterNames[i-1])
+				}
+			},
+		})
+	}
+
+	return actions
+}
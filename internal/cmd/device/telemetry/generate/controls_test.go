@@ -0,0 +1,93 @@
+package generate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+func TestGeneratorControls(t *testing.T) {
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	device := testDevice("DEV-1")
+
+	v := view.TelemetryTable{Devices: []*aware.Device{device}}
+	g := newGenerator(aware.NewClient(aware.Config{Server: server.URL}), []*aware.Device{device}, &generateParams{
+		frequency:     time.Hour,
+		concurrency:   1,
+		flushInterval: 5 * time.Millisecond,
+	}, nil, v.FormatRow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		g.run(ctx)
+	}()
+
+	// Publishing now works while paused and skips disabled parameters
+	g.togglePause()
+	g.toggleParameter("Broken")
+	is.True(strings.HasPrefix(g.status(), "paused, every 1h0m0s, off: Broken"))
+
+	g.publishNow()
+	row := <-g.Rows()
+	is.True(row[1] != "") // Voltage
+	is.Equal(row[2], "")  // Broken
+
+	// The next values are anomalous, voltages are at most 300 otherwise
+	g.toggleParameter("Broken")
+	g.injectAnomaly()
+	is.True(strings.Contains(g.status(), "anomaly pending"))
+
+	g.publishNow()
+	row = <-g.Rows()
+	voltage, err := strconv.ParseFloat(row[1], 64)
+	is.NoErr(err)
+	is.True(voltage >= 2000)
+	is.True(row[2] != "")
+	is.True(!strings.Contains(g.status(), "anomaly pending"))
+
+	g.togglePause()
+	g.scaleFrequency(0.5)
+	is.True(strings.HasPrefix(g.status(), "publishing every 30m0s"))
+
+	// A cron schedule is left as it is
+	cron, err := parseSchedule("*/15 * * * *")
+	is.NoErr(err)
+	g.ctl.Lock()
+	g.schedules.fallback.spec = "*/15 * * * *"
+	g.schedules.fallback.schedule = cron
+	g.ctl.Unlock()
+	g.scaleFrequency(2)
+	is.True(strings.HasPrefix(g.status(), "publishing every */15 * * * *"))
+
+	cancel()
+	<-stopped
+	is.NoErr(g.close())
+}
+
+func TestAnomalous(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(anomalous(2.5), 25.0)
+	is.Equal(anomalous(0.0), 10.0)
+	is.Equal(anomalous(3), 30)
+	is.Equal(anomalous(true), false)
+	is.Equal(anomalous("ok"), "ok")
+}
@@ -2,50 +2,100 @@
 package generate
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"sync"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"ampaware.com/cli/internal/spool"
 	"ampaware.com/cli/internal/utils"
 	"ampaware.com/cli/internal/view"
 	"ampaware.com/cli/pkg/aware"
-	"ampaware.com/cli/pkg/tui/table"
+	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+type generateParams struct {
+	IDs            []string
+	parameters     []string
+	all            bool
+	deviceTypeKind string
+	entityID       string
+	selectDevices  bool
+	singleValue    bool
+	frequency      time.Duration
+	schedules      map[string]timing
+	jitter         time.Duration
+	concurrency    int
+	batchSize      int
+	flushInterval  time.Duration
+	plain          bool
+	noHeaders      bool
+	noSpool        bool
+	report         string
+}
+
+type generateCmd struct {
+	client      *aware.Client
+	params      *generateParams
+	devices     []*aware.Device
+	interactive bool
+}
+
 // NewCmdDeviceTelemetryGenerate is the command for generating device telemetry.
 func NewCmdDeviceTelemetryGenerate() *cobra.Command {
 	cmd := cobra.Command{
-		Use:         "generate",
-		Short:       "Generate telemetry for a device",
-		Long:        "Generate telemetry for a Device", // TODO: Fix
-		Example:     "Should do something here",        // TODO: Fix
+		Use:   "generate [ID...]",
+		Short: "Generate telemetry for one or more devices",
+		Long: `Generate telemetry for one or more devices.
+
+Devices can be given as IDs, or selected with --all, --type, --entity or --select.
+When using the filters every matching device will have telemetry generated.
+If no devices are given a device can be picked from a list, followed by which
+of its parameters to publish.
+
+Every parameter is published at the frequency given by --frequency-seconds and
+--frequency-minutes, unless it has its own --schedule. Schedules are given as
+PARAMETER=SCHEDULE, where PARAMETER is a parameter name or display name, or
+"type:" followed by a value type such as type:float. A schedule for a parameter
+is used before one for its value type.
+
+A schedule is an interval such as 1s or "@every 15m", a cron expression with five
+fields (minute hour day-of-month month day-of-week) or six with seconds first,
+or one of @hourly, @daily, @weekly, @monthly and @yearly. Adding "~" and a
+duration delays each publish by a random amount up to that duration, --jitter
+does the same for parameters without their own.
+
+Parameters sharing a schedule are published together, other parameters are left
+empty in that row.
+
+While the table is shown, p pauses and resumes publishing, n publishes every
+parameter now, + and - halve and double the interval of parameters without their
+own schedule, 1-9 turn the parameter in that position on or off and a makes the
+next values of every device anomalous. Press ? to list the keys.
+
+When the generator stops a summary of the values sent for each parameter is
+printed, --report also writes it to a JSON file.`,
+		Example: `aware device telemetry generate 5d1d574439d157849090ea6a
+aware device telemetry generate --type integrated-protection-relay --frequency-seconds 10
+aware device telemetry generate --entity 5cf48c71b2f30979bc612292 --plain
+aware device telemetry generate 5d1d574439d157849090ea6a --parameters pilot-forward-resistance
+aware device telemetry generate 5d1d574439d157849090ea6a --frequency-seconds 1 --schedule "energy=*/15 * * * * ~30s"
+aware device telemetry generate --type integrated-protection-relay --schedule type:float=5s --jitter 2s
+aware device telemetry generate 5d1d574439d157849090ea6a --report run.json`,
 		Aliases:     []string{},
 		Annotations: map[string]string{},
-		Args:        cobra.MinimumNArgs(1),
 		Run:         generate,
-		// TODO: Help for Args
 	}
 
 	return &cmd
 }
 
 func generate(cmd *cobra.Command, args []string) {
-	// TODO: Get rid of min args - give device list
-
-	// Default will generate a telemetry value for each parameter once per 10 seconds
-	deviceID := args[0]
-
-	singleValue, err := cmd.Flags().GetBool("single-value")
-	utils.ExitIfError(err)
-
-	frequencySeconds, err := cmd.Flags().GetInt("frequency-seconds")
-	utils.ExitIfError(err)
-
-	frequencyMinutes, err := cmd.Flags().GetInt("frequency-minutes")
-	utils.ExitIfError(err)
+	params := parseFlagsAndArgs(cmd, args)
 
 	client := aware.NewClient(aware.Config{
 		Server:   viper.GetString("server"),
@@ -54,97 +104,333 @@ func generate(cmd *cobra.Command, args []string) {
 		Debug:    viper.GetBool("debug"),
 	})
 
-	device, err := func() (*aware.Device, error) {
-		s := utils.ShowLoading("Fetching Device...")
-		defer s.Stop()
+	gen := generateCmd{
+		client: client,
+		params: params,
+	}
+
+	utils.ExitIfError(gen.setDevices())
 
-		resp, err := client.GetDeviceByID(deviceID)
-		if err != nil {
-			return nil, err
+	switch {
+	case gen.interactive:
+		utils.ExitIfError(gen.getDevice())
+	case gen.params.selectDevices:
+		utils.ExitIfError(gen.selectDevices())
+	}
+
+	if len(gen.devices) == 0 {
+		utils.Failed("No devices found to generate telemetry for")
+	}
+
+	if len(gen.params.parameters) > 0 {
+		utils.ExitIfError(gen.filterParameters())
+	} else if gen.interactive || gen.params.selectDevices {
+		utils.ExitIfError(gen.selectParameters())
+	}
+
+	utils.ExitIfError(gen.checkSchedules())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+	go func() {
+		select {
+		case <-signalChan:
+			cancel()
+		case <-ctx.Done():
 		}
-		return resp, nil
 	}()
-	utils.ExitIfError(err)
-
-	appendReady := make(chan byte)
-	appendRow := publishValuesToRow(client, device)
-	utils.ExitIfError(err)
 
 	t := view.TelemetryTable{
-		Parameters: &device.DeviceType.Parameters,
+		Devices: gen.devices,
 		Display: view.TelemetryTableDisplayFormat{
-			Plain:        false,
-			NoHeaders:    false,
+			Plain:        params.plain,
+			NoHeaders:    params.noHeaders,
 			StickyCursor: true,
 		},
-		AppendRow:   &appendRow,
-		AppendReady: appendReady,
-		InitialRows: []table.Row{appendRow},
-	}
-
-	if !singleValue {
-		timeTicker := (time.Duration(frequencySeconds)*time.Second +
-			time.Duration(frequencyMinutes)*time.Minute)
-		ticker := time.NewTicker(timeTicker)
-		signalChan := make(chan os.Signal, 1)
-		quit := make(chan struct{})
-		go func() {
-			for {
-				select {
-				case <-ticker.C:
-					appendRow = publishValuesToRow(client, device)
-					utils.ExitIfError(err)
-					appendReady <- 1
-				case <-signalChan:
-					ticker.Stop()
-					return
-				case <-quit:
-					ticker.Stop()
-					return
-				}
-			}
-		}()
+		Quit: ctx.Done(),
+	}
+
+	var failures *spool.Spool
+	if !params.noSpool {
+		file, err := spool.DefaultPath()
+		utils.ExitIfError(err)
+
+		failures, err = spool.Open(file)
+		utils.ExitIfError(err)
 	}
 
+	g := newGenerator(client, gen.devices, params, failures, t.FormatRow)
+	t.Rows = g.Rows()
+	t.Status = g.status
+	t.Actions = g.actions()
+
+	// Every device publishes its first values before the view is shown
+	t.InitialRows = g.publishAll(ctx)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		if params.singleValue {
+			close(g.rows)
+			return
+		}
+		g.run(ctx)
+	}()
+
 	utils.ExitIfError(t.Render())
+
+	cancel()
+	<-stopped
+
+	err := func() error {
+		s := utils.ShowLoading("Delivering remaining values...")
+		defer s.Stop()
+
+		return g.close()
+	}()
+	utils.ExitIfError(err)
+
+	utils.Success("Generator stopped")
+	fmt.Println()
+
+	report := g.report()
+	utils.ExitIfError(writeSummary(os.Stdout, report))
+
+	if params.report != "" {
+		utils.ExitIfError(writeReport(params.report, report))
+		utils.Success("Run report written to %s", params.report)
+	}
+
+	if failures != nil && failures.Count() > 0 {
+		utils.Warn("Failed values were saved, run 'aware telemetry replay' to publish them.")
+	}
+}
+
+func (g *generateCmd) setDevices() error {
+	if len(g.params.IDs) > 0 {
+		s := utils.ShowLoading("Fetching Devices...")
+		defer s.Stop()
+
+		for _, id := range g.params.IDs {
+			device, err := g.client.GetDeviceByID(id)
+			if err != nil {
+				return err
+			}
+			g.devices = append(g.devices, device)
+		}
+		return nil
+	}
+
+	// Without any devices or filters the device is picked from the list
+	g.interactive = !g.params.all && g.params.deviceTypeKind == "" && g.params.entityID == "" && !g.params.selectDevices
+
+	s := utils.ShowLoading("Fetching Devices...")
+	defer s.Stop()
+
+	devices, err := g.client.GetAllDevices(aware.GetAllDevicesOptions{
+		OrganisationID: viper.GetString("organisation"),
+		DeviceTypeKind: g.params.deviceTypeKind,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, device := range devices {
+		if g.params.deviceTypeKind != "" && device.DeviceType.Kind != g.params.deviceTypeKind {
+			continue
+		}
+		if g.params.entityID != "" && !device.ParentEntity.IsUnder(g.params.entityID) {
+			continue
+		}
+		g.devices = append(g.devices, device)
+	}
+
+	return nil
+}
+
+func (g *generateCmd) getDevice() error {
+	var ans string
+
+	options := deviceOptions(g.devices)
+
+	qs := &survey.Question{
+		Name: "id",
+		Prompt: &survey.Select{
+			Message: "Device:",
+			Options: options,
+			Description: func(value string, index int) string {
+				return g.devices[index].DeviceType.Name
+			},
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return err
+	}
+
+	for i, device := range g.devices {
+		if ans == options[i] {
+			g.devices = []*aware.Device{device}
+			break
+		}
+	}
+
+	return nil
+}
+
+func (g *generateCmd) selectDevices() error {
+	var ans []string
+
+	options := deviceOptions(g.devices)
+
+	qs := &survey.Question{
+		Name: "devices",
+		Prompt: &survey.MultiSelect{
+			Message: "Devices:",
+			Options: options,
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return err
+	}
+
+	selected := make(map[string]struct{}, len(ans))
+	for _, a := range ans {
+		selected[a] = struct{}{}
+	}
+
+	devices := make([]*aware.Device, 0, len(ans))
+	for i, device := range g.devices {
+		if _, ok := selected[options[i]]; ok {
+			devices = append(devices, device)
+		}
+	}
+	g.devices = devices
+
+	return nil
 }
 
-func publishParameterValues(client *aware.Client, device *aware.Device) (time.Time, []interface{}) {
-	var wg sync.WaitGroup
-	ts := time.Now()
-	publishedValues := make([]interface{}, 0)
-	for _, parameter := range device.DeviceType.Parameters {
-		value := parameter.GetRandomValue()
-		publishedValues = append(publishedValues, value)
+// selectParameters asks which parameters to publish, once for each device type
+// being generated.
+func (g *generateCmd) selectParameters() error {
+	selected := make(map[string][]aware.DeviceTypeParameter)
 
-		wg.Add(1)
+	for _, device := range g.devices {
+		deviceType := device.DeviceType
+		if _, ok := selected[deviceType.ID]; !ok {
+			parameters, err := askParameters(&deviceType)
+			if err != nil {
+				return err
+			}
+			selected[deviceType.ID] = parameters
+		}
+		device.DeviceType.Parameters = selected[deviceType.ID]
+	}
 
-		go func(parameter aware.DeviceTypeParameter) {
-			defer wg.Done()
-			utils.ExitIfError(client.PublishTelemetry(
-				device.ID,
-				parameter.Name,
-				value,
-				ts,
-			))
-		}(parameter)
+	return nil
+}
+
+// filterParameters keeps only the parameters given by --parameters, by name or display name.
+func (g *generateCmd) filterParameters() error {
+	wanted := make(map[string]struct{}, len(g.params.parameters))
+	for _, name := range g.params.parameters {
+		wanted[name] = struct{}{}
 	}
 
-	wg.Wait()
+	found := make(map[string]struct{}, len(wanted))
+	for _, device := range g.devices {
+		parameters := make([]aware.DeviceTypeParameter, 0, len(wanted))
+		for _, parameter := range device.DeviceType.Parameters {
+			_, byName := wanted[parameter.Name]
+			_, byDisplayName := wanted[parameter.DisplayName]
+			if byName || byDisplayName {
+				parameters = append(parameters, parameter)
+				found[parameter.Name] = struct{}{}
+				found[parameter.DisplayName] = struct{}{}
+			}
+		}
+		device.DeviceType.Parameters = parameters
+	}
 
-	return ts, publishedValues
+	for name := range wanted {
+		if _, ok := found[name]; !ok {
+			return fmt.Errorf("no device has a parameter named %s", name)
+		}
+	}
+
+	return nil
 }
 
-func publishValuesToRow(client *aware.Client, device *aware.Device) table.Row {
-	ts, values := publishParameterValues(client, device)
+// checkSchedules makes sure every schedule is for a parameter or value type of a device being generated.
+func (g *generateCmd) checkSchedules() error {
+	used := make(map[string]struct{})
+	for _, device := range g.devices {
+		for _, parameter := range device.DeviceType.Parameters {
+			used[parameter.Name] = struct{}{}
+			used[parameter.DisplayName] = struct{}{}
+			used[valueTypePrefix+string(parameter.ValueType)] = struct{}{}
+		}
+	}
 
-	var row table.Row
-	row = append(row, ts.Format(time.RFC3339))
-	for _, val := range values {
-		row = append(row, fmt.Sprintf("%v", val))
+	for key := range g.params.schedules {
+		if _, ok := used[key]; !ok {
+			return fmt.Errorf("no parameter or value type matching schedule %q", key)
+		}
 	}
 
-	return row
+	return nil
+}
+
+func askParameters(deviceType *aware.DeviceType) ([]aware.DeviceTypeParameter, error) {
+	var ans []string
+
+	options := make([]string, 0, len(deviceType.Parameters))
+	for _, parameter := range deviceType.Parameters {
+		options = append(options, parameter.DisplayName)
+	}
+
+	qs := &survey.Question{
+		Name: "parameters",
+		Prompt: &survey.MultiSelect{
+			Message: fmt.Sprintf("Parameters to publish for %s:", deviceType.Name),
+			Options: options,
+			Default: options,
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]struct{}, len(ans))
+	for _, a := range ans {
+		selected[a] = struct{}{}
+	}
+
+	parameters := make([]aware.DeviceTypeParameter, 0, len(ans))
+	for _, parameter := range deviceType.Parameters {
+		if _, ok := selected[parameter.DisplayName]; ok {
+			parameters = append(parameters, parameter)
+		}
+	}
+
+	return parameters, nil
+}
+
+func deviceOptions(devices []*aware.Device) []string {
+	options := make([]string, 0, len(devices))
+	for _, device := range devices {
+		options = append(options, device.ID+" - "+device.ParentEntity.GetParentHierachyName()+" - "+device.DisplayName)
+	}
+	return options
 }
 
 // SetFlags sets all the flags for the command.
@@ -152,4 +438,107 @@ func SetFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolP("single-value", "s", false, "Only generates a single value for each parameter")
 	cmd.Flags().Int("frequency-seconds", 30, "The second frequency in which to generate values")
 	cmd.Flags().Int("frequency-minutes", 0, "The minute frequency in which to generate values")
+	cmd.Flags().StringArray("schedule", nil, "Publish a parameter or value type on its own schedule, as PARAMETER=SCHEDULE")
+	cmd.Flags().Duration("jitter", 0, "Delay each publish by a random amount up to this, for parameters without their own jitter")
+	cmd.Flags().Bool("all", false, "Generate telemetry for every device in the organisation")
+	cmd.Flags().String("type", "", "Generate telemetry for every device of the given device type kind")
+	cmd.Flags().String("entity", "", "Generate telemetry for every device under the given entity")
+	cmd.Flags().Bool("select", false, "Choose the devices to generate telemetry for from a list")
+	cmd.Flags().StringSlice("parameters", nil, "Only publish the parameters with these names or display names")
+	cmd.Flags().Int("concurrency", 4, "Maximum number of devices publishing at once")
+	cmd.Flags().Int("batch-size", 100, "Maximum number of values sent to AWARE in one request")
+	cmd.Flags().Duration("flush-interval", time.Second, "Longest time a value waits before being sent to AWARE")
+	cmd.Flags().Bool("no-spool", false, "Don't save values that fail to publish for replaying later")
+	cmd.Flags().String("report", "", "Write a JSON report of the run to this file when the generator stops")
+	cmd.Flags().Bool("plain", false, "Display output in plain mode")
+	cmd.Flags().Bool("no-headers", false, "Don't display headers in plain mode. Works only with --plain")
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *generateParams {
+	singleValue, err := cmd.Flags().GetBool("single-value")
+	utils.ExitIfError(err)
+
+	frequencySeconds, err := cmd.Flags().GetInt("frequency-seconds")
+	utils.ExitIfError(err)
+
+	frequencyMinutes, err := cmd.Flags().GetInt("frequency-minutes")
+	utils.ExitIfError(err)
+
+	frequency := time.Duration(frequencySeconds)*time.Second + time.Duration(frequencyMinutes)*time.Minute
+	if frequency <= 0 {
+		utils.Failed("Frequency must be greater than zero")
+	}
+
+	scheduleValues, err := cmd.Flags().GetStringArray("schedule")
+	utils.ExitIfError(err)
+
+	schedules, err := parseSchedules(scheduleValues)
+	utils.ExitIfError(err)
+
+	jitter, err := cmd.Flags().GetDuration("jitter")
+	utils.ExitIfError(err)
+
+	if jitter < 0 {
+		utils.Failed("--jitter can't be negative")
+	}
+
+	all, err := cmd.Flags().GetBool("all")
+	utils.ExitIfError(err)
+
+	deviceTypeKind, err := cmd.Flags().GetString("type")
+	utils.ExitIfError(err)
+
+	entityID, err := cmd.Flags().GetString("entity")
+	utils.ExitIfError(err)
+
+	selectDevices, err := cmd.Flags().GetBool("select")
+	utils.ExitIfError(err)
+
+	parameters, err := cmd.Flags().GetStringSlice("parameters")
+	utils.ExitIfError(err)
+
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	utils.ExitIfError(err)
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	batchSize, err := cmd.Flags().GetInt("batch-size")
+	utils.ExitIfError(err)
+
+	flushInterval, err := cmd.Flags().GetDuration("flush-interval")
+	utils.ExitIfError(err)
+
+	plain, err := cmd.Flags().GetBool("plain")
+	utils.ExitIfError(err)
+
+	noHeaders, err := cmd.Flags().GetBool("no-headers")
+	utils.ExitIfError(err)
+
+	noSpool, err := cmd.Flags().GetBool("no-spool")
+	utils.ExitIfError(err)
+
+	report, err := cmd.Flags().GetString("report")
+	utils.ExitIfError(err)
+
+	return &generateParams{
+		IDs:            args,
+		parameters:     parameters,
+		all:            all,
+		deviceTypeKind: deviceTypeKind,
+		entityID:       entityID,
+		selectDevices:  selectDevices,
+		singleValue:    singleValue,
+		frequency:      frequency,
+		schedules:      schedules,
+		jitter:         jitter,
+		concurrency:    concurrency,
+		batchSize:      batchSize,
+		flushInterval:  flushInterval,
+		plain:          plain,
+		noHeaders:      noHeaders,
+		noSpool:        noSpool,
+		report:         report,
+	}
 }
@@ -0,0 +1,440 @@
+package generate
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ampaware.com/cli/internal/spool"
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/table"
+)
+
+// formatRowFunc formats the published values of a device into a row.
+type formatRowFunc func(device *aware.Device, ts time.Time, values []interface{}) table.Row
+
+// generator publishes random values for devices on their schedules and sends a
+// row for each round of published values once they have been delivered.
+type generator struct {
+	publisher   *aware.Publisher
+	devices     []*aware.Device
+	schedules   schedules
+	concurrency int
+	format      formatRowFunc
+
+	ctl            sync.Mutex
+	controls       controls
+	parameterNames []string
+	now            chan struct{}
+	wake           chan struct{}
+
+	rows  chan table.Row
+	spool *spool.Spool
+
+	mu      sync.Mutex
+	pending map[string]chan error
+
+	published uint64
+	failed    uint64
+	lastError atomic.Value
+	stats     *runStats
+}
+
+// newGenerator creates a generator, values that fail to publish are written to
+// the spool if one is given.
+func newGenerator(client *aware.Client, devices []*aware.Device, params *generateParams, s *spool.Spool, format formatRowFunc) *generator {
+	g := &generator{
+		spool:       s,
+		devices:     devices,
+		concurrency: params.concurrency,
+		format:      format,
+		rows:        make(chan table.Row),
+		pending:     make(map[string]chan error),
+		controls: controls{
+			disabled:  make(map[string]struct{}),
+			anomalies: make(map[string]struct{}),
+		},
+		parameterNames: (&view.TelemetryTable{Devices: devices}).ParameterNames(),
+		now:            make(chan struct{}, 1),
+		wake:           make(chan struct{}, 1),
+		stats:          newRunStats(),
+	}
+
+	g.schedules = schedules{
+		fallback: timing{
+			spec:     params.frequency.String(),
+			schedule: interval(params.frequency),
+			jitter:   params.jitter,
+		},
+		byKey: make(map[string]timing, len(params.schedules)),
+	}
+
+	// Schedules without their own jitter use the same jitter as the fallback
+	for key, t := range params.schedules {
+		if t.jitter == 0 {
+			t.jitter = params.jitter
+		}
+		g.schedules.byKey[key] = t
+	}
+
+	g.publisher = client.NewPublisher(aware.PublisherConfig{
+		BatchSize:     params.batchSize,
+		FlushInterval: params.flushInterval,
+		OnDelivery:    g.onDelivery,
+	})
+
+	return g
+}
+
+// Rows is the channel of rows, it is closed once the generator has stopped.
+func (g *generator) Rows() <-chan table.Row {
+	return g.rows
+}
+
+// publishAll publishes a round of values for every device at once.
+func (g *generator) publishAll(ctx context.Context) []table.Row {
+	var wg sync.WaitGroup
+
+	workers := make(chan struct{}, g.concurrency)
+	rows := make([]table.Row, len(g.devices))
+	for i, device := range g.devices {
+		wg.Add(1)
+		go func(i int, device *aware.Device) {
+			defer wg.Done()
+
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			rows[i] = g.publish(ctx, device, nil)
+		}(i, device)
+	}
+	wg.Wait()
+
+	out := make([]table.Row, 0, len(rows))
+	for _, row := range rows {
+		if row != nil {
+			out = append(out, row)
+		}
+	}
+
+	return out
+}
+
+// run publishes values for every device as their schedules come due until the
+// context is done. Parameters sharing a schedule are published together in one row,
+// devices on the fallback schedule are staggered so they don't all publish at once.
+// Nothing is published on the schedules while paused.
+func (g *generator) run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	defer close(g.rows)
+	defer wg.Wait()
+
+	queue := g.jobs(time.Now())
+	heap.Init(&queue)
+
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	workers := make(chan struct{}, g.concurrency)
+	dispatch := func(j *job) bool {
+		// A job still publishing its previous values skips this time, like a ticker
+		select {
+		case j.busy <- struct{}{}:
+		default:
+			return true
+		}
+
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+			<-j.busy
+			return false
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-j.busy }()
+
+			row := g.publish(ctx, j.device, j.parameters)
+			<-workers
+
+			if row == nil {
+				return
+			}
+
+			select {
+			case g.rows <- row:
+			case <-ctx.Done():
+			}
+		}()
+
+		return true
+	}
+
+	for {
+		var due <-chan time.Time
+		if queue.Len() > 0 {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(time.Until(queue[0].at))
+			due = timer.C
+		}
+
+		select {
+		case <-due:
+		case <-g.now:
+			for _, j := range queue {
+				if !dispatch(j) {
+					return
+				}
+			}
+			continue
+		case <-g.wake:
+			g.replan(queue, time.Now())
+			heap.Init(&queue)
+			continue
+		case <-ctx.Done():
+			return
+		}
+
+		j := queue[0]
+		j.due = j.nextDue(time.Now())
+		if j.due.IsZero() {
+			heap.Pop(&queue)
+		} else {
+			j.at = j.timing.at(j.due)
+			heap.Fix(&queue, 0)
+		}
+
+		if g.isPaused() {
+			continue
+		}
+		if !dispatch(j) {
+			return
+		}
+	}
+}
+
+// jobs groups the parameters of each device by their schedule.
+func (g *generator) jobs(now time.Time) jobQueue {
+	var queue jobQueue
+
+	g.ctl.Lock()
+	schedules := g.schedules
+	g.ctl.Unlock()
+
+	for i, device := range g.devices {
+		offset := schedules.fallback.schedule.next(now).Sub(now) / time.Duration(len(g.devices)) * time.Duration(i)
+
+		fallback := &job{
+			device:   device,
+			timing:   schedules.fallback,
+			fallback: true,
+			due:      schedules.fallback.schedule.next(now.Add(offset)),
+			busy:     make(chan struct{}, 1),
+		}
+		fallback.at = fallback.timing.at(fallback.due)
+
+		bySpec := make(map[string]*job)
+		for p, parameter := range device.DeviceType.Parameters {
+			t, ok := schedules.own(parameter)
+			if !ok {
+				fallback.parameters = append(fallback.parameters, p)
+				continue
+			}
+
+			if j, ok := bySpec[t.spec]; ok {
+				j.parameters = append(j.parameters, p)
+				continue
+			}
+
+			j := &job{
+				device:     device,
+				parameters: []int{p},
+				timing:     t,
+				due:        t.schedule.next(now),
+				busy:       make(chan struct{}, 1),
+			}
+			if j.due.IsZero() {
+				continue
+			}
+			j.at = t.at(j.due)
+
+			bySpec[t.spec] = j
+			queue = append(queue, j)
+		}
+
+		if len(fallback.parameters) > 0 {
+			queue = append(queue, fallback)
+		}
+	}
+
+	return queue
+}
+
+// replan moves the jobs on the fallback schedule to its current timing.
+func (g *generator) replan(queue jobQueue, now time.Time) {
+	fallback := g.fallback()
+	for _, j := range queue {
+		if j.fallback && j.timing.spec != fallback.spec {
+			j.timing = fallback
+			j.due = fallback.schedule.next(now)
+			j.at = fallback.at(j.due)
+		}
+	}
+}
+
+// publish publishes a value for the given parameters of the device, or every
+// parameter when none are given, and waits for them to be delivered. Disabled
+// parameters are skipped, returning nil if there is nothing to publish. Values that
+// failed to deliver are marked in the row and parameters not published are empty.
+func (g *generator) publish(ctx context.Context, device *aware.Device, parameters []int) table.Row {
+	ts := time.Now()
+
+	if parameters == nil {
+		parameters = make([]int, len(device.DeviceType.Parameters))
+		for i := range parameters {
+			parameters[i] = i
+		}
+	}
+
+	parameters = g.enabled(device, parameters)
+	if len(parameters) == 0 {
+		return nil
+	}
+	anomaly := g.takeAnomaly(device)
+
+	values := make([]interface{}, len(device.DeviceType.Parameters))
+	results := make(map[int]chan error, len(parameters))
+	queued := make([]aware.TelemetryValue, 0, len(parameters))
+	for _, i := range parameters {
+		parameter := device.DeviceType.Parameters[i]
+		value := aware.TelemetryValue{
+			DeviceID:      device.ID,
+			ParameterName: parameter.Name,
+			Value:         parameter.GetRandomValue(ts),
+			Timestamp:     ts,
+		}
+		if anomaly {
+			value.Value = anomalous(value.Value)
+		}
+		values[i] = value.Value
+		results[i] = g.expect(value)
+
+		if err := g.publisher.Publish(ctx, value); err != nil {
+			// Values already queued are still delivered and counted, but nothing waits
+			// for them. The value that couldn't be queued is failed like an undelivered one.
+			for _, v := range queued {
+				g.forget(v)
+			}
+			g.onDelivery(aware.DeliveryResult{Value: value, Err: err})
+			return nil
+		}
+		queued = append(queued, value)
+	}
+
+	for i, result := range results {
+		select {
+		case err := <-result:
+			if err != nil {
+				values[i] = view.FailedValue{Value: values[i]}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	return g.format(device, ts, values)
+}
+
+func (g *generator) expect(v aware.TelemetryValue) chan error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	result := make(chan error, 1)
+	g.pending[deliveryKey(v)] = result
+	return result
+}
+
+func (g *generator) forget(v aware.TelemetryValue) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.pending, deliveryKey(v))
+}
+
+func (g *generator) onDelivery(r aware.DeliveryResult) {
+	g.stats.record(r)
+
+	atomic.AddUint64(&g.published, 1)
+	if r.Err != nil {
+		atomic.AddUint64(&g.failed, 1)
+		g.lastError.Store(r.Err.Error())
+
+		if g.spool != nil {
+			if err := g.spool.Append(r.Value); err != nil {
+				g.lastError.Store(err.Error())
+			}
+		}
+	}
+
+	g.mu.Lock()
+	result, ok := g.pending[deliveryKey(r.Value)]
+	delete(g.pending, deliveryKey(r.Value))
+	g.mu.Unlock()
+
+	if ok {
+		result <- r.Err
+	}
+}
+
+// close delivers any remaining values.
+func (g *generator) close() error {
+	if err := g.publisher.Close(); err != nil {
+		return err
+	}
+
+	if g.spool != nil {
+		return g.spool.Close()
+	}
+	return nil
+}
+
+// report summarises the values sent so far.
+func (g *generator) report() *runReport {
+	spooled := 0
+	if g.spool != nil {
+		spooled = g.spool.Count()
+	}
+	return g.stats.report(g.devices, spooled)
+}
+
+// status describes the published and failed counts for display.
+func (g *generator) status() string {
+	published := atomic.LoadUint64(&g.published)
+	failed := atomic.LoadUint64(&g.failed)
+
+	status := fmt.Sprintf("%s | %d published, %d failed", g.controlsStatus(), published-failed, failed)
+	if g.spool != nil && g.spool.Count() > 0 {
+		status += fmt.Sprintf(", %d spooled", g.spool.Count())
+	}
+	if lastError, ok := g.lastError.Load().(string); ok && failed > 0 {
+		status += fmt.Sprintf(" (last error: %s)", lastError)
+	}
+
+	return status
+}
+
+func deliveryKey(v aware.TelemetryValue) string {
+	return fmt.Sprintf("%s|%s|%d", v.DeviceID, v.ParameterName, v.Timestamp.UnixNano())
+}
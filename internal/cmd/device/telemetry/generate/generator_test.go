@@ -0,0 +1,166 @@
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/table"
+	"github.com/matryer/is"
+)
+
+func TestGeneratorRun(t *testing.T) {
+	is := is.New(t)
+
+	// Bulk isn't supported and every value for the "broken" parameter fails
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/ingestion/ingest" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var body struct {
+			ParameterName string `json:"parameter"`
+		}
+		is.NoErr(json.NewDecoder(r.Body).Decode(&body))
+
+		if body.ParameterName == "broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	devices := []*aware.Device{
+		testDevice("DEV-1"),
+		testDevice("DEV-2"),
+	}
+
+	v := view.TelemetryTable{Devices: devices}
+	g := newGenerator(aware.NewClient(aware.Config{Server: server.URL}), devices, &generateParams{
+		frequency:     10 * time.Millisecond,
+		concurrency:   2,
+		flushInterval: 5 * time.Millisecond,
+	}, nil, v.FormatRow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	initial := g.publishAll(ctx)
+	is.Equal(len(initial), 2)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		g.run(ctx)
+	}()
+
+	var rows []table.Row
+	for row := range g.Rows() {
+		rows = append(rows, row)
+		if len(rows) == 6 {
+			cancel()
+		}
+	}
+	<-stopped
+	is.NoErr(g.close())
+
+	is.True(len(rows) >= 6)
+
+	// Time, Device, Voltage, Broken
+	for _, row := range append(initial, rows...) {
+		is.Equal(len(row), 4)
+		is.True(!strings.HasPrefix(row[2], table.FailedCellPrefix))
+		is.True(strings.HasPrefix(row[3], table.FailedCellPrefix))
+	}
+
+	is.True(strings.Contains(g.status(), "failed"))
+	is.True(g.failed > 0)
+}
+
+func TestGeneratorRunSchedules(t *testing.T) {
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	device := testDevice("DEV-1")
+
+	// Voltage is published often, the other parameter hardly ever
+	v := view.TelemetryTable{Devices: []*aware.Device{device}}
+	g := newGenerator(aware.NewClient(aware.Config{Server: server.URL}), []*aware.Device{device}, &generateParams{
+		frequency:     time.Hour,
+		schedules:     map[string]timing{"type:float": {spec: "10ms", schedule: interval(10 * time.Millisecond)}, "broken": {spec: "@yearly", schedule: interval(time.Hour)}},
+		concurrency:   1,
+		flushInterval: 5 * time.Millisecond,
+	}, nil, v.FormatRow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		g.run(ctx)
+	}()
+
+	var rows []table.Row
+	for row := range g.Rows() {
+		rows = append(rows, row)
+		if len(rows) == 3 {
+			cancel()
+		}
+	}
+	<-stopped
+	is.NoErr(g.close())
+
+	// Time, Voltage, Broken
+	for _, row := range rows {
+		is.Equal(len(row), 3)
+		is.True(row[1] != "")
+		is.Equal(row[2], "")
+	}
+}
+
+func testDevice(id string) *aware.Device {
+	return &aware.Device{
+		ID:          id,
+		DisplayName: id,
+		DeviceType: aware.DeviceType{
+			ID:   "TYPE-1",
+			Name: "Test",
+			Parameters: []aware.DeviceTypeParameter{
+				{Name: "voltage", DisplayName: "Voltage", ValueType: aware.Float},
+				{Name: "broken", DisplayName: "Broken", ValueType: aware.Float},
+			},
+		},
+	}
+}
+
+func TestGeneratorPublishClosed(t *testing.T) {
+	is := is.New(t)
+
+	device := testDevice("DEV-1")
+
+	v := view.TelemetryTable{Devices: []*aware.Device{device}}
+	g := newGenerator(aware.NewClient(aware.Config{Server: "http://127.0.0.1:0"}), []*aware.Device{device}, &generateParams{
+		frequency:   time.Hour,
+		concurrency: 1,
+	}, nil, v.FormatRow)
+	is.NoErr(g.close())
+
+	// The value that can't be queued is failed and nothing is left waiting on the tick
+	is.Equal(g.publish(context.Background(), device, nil), nil)
+	is.Equal(g.failed, uint64(1))
+	is.Equal(len(g.pending), 0)
+	is.True(strings.Contains(g.status(), aware.ErrPublisherClosed.Error()))
+}
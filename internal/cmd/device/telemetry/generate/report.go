@@ -0,0 +1,282 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+)
+
+// maxReportErrors is the most distinct errors kept for the report, later errors are counted as other.
+const maxReportErrors = 10
+
+// otherErrors is the error recorded once there are too many distinct errors.
+const otherErrors = "other errors"
+
+// runStats records every value delivered by the generator for the run report.
+// Only running totals are kept so long runs don't use more memory.
+type runStats struct {
+	mu         sync.Mutex
+	started    time.Time
+	parameters map[string]*parameterStats // By parameter name
+	errors     map[string]uint64
+}
+
+// parameterStats are the running totals for a parameter across every device.
+type parameterStats struct {
+	published uint64
+	failed    uint64
+	values    summary
+	latency   summary
+}
+
+// summary is the running minimum, maximum and mean of a set of numbers.
+type summary struct {
+	count uint64
+	min   float64
+	max   float64
+	sum   float64
+}
+
+func (s *summary) add(v float64) {
+	if s.count == 0 || v < s.min {
+		s.min = v
+	}
+	if s.count == 0 || v > s.max {
+		s.max = v
+	}
+	s.count++
+	s.sum += v
+}
+
+func newRunStats() *runStats {
+	return &runStats{
+		started:    time.Now(),
+		parameters: make(map[string]*parameterStats),
+		errors:     make(map[string]uint64),
+	}
+}
+
+func (s *runStats) record(r aware.DeliveryResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.parameters[r.Value.ParameterName]
+	if !ok {
+		p = &parameterStats{}
+		s.parameters[r.Value.ParameterName] = p
+	}
+
+	p.published++
+	p.latency.add(milliseconds(r.Latency))
+	if v, ok := number(r.Value.Value); ok {
+		p.values.add(v)
+	}
+
+	if r.Err != nil {
+		p.failed++
+
+		msg := r.Err.Error()
+		if _, ok := s.errors[msg]; !ok && len(s.errors) >= maxReportErrors {
+			msg = otherErrors
+		}
+		s.errors[msg]++
+	}
+}
+
+// number returns the value as a float if it is a number.
+func number(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// runReport is the summary of a generator run.
+type runReport struct {
+	Started    time.Time         `json:"started"`
+	Ended      time.Time         `json:"ended"`
+	Duration   float64           `json:"durationSeconds"`
+	Devices    []reportDevice    `json:"devices"`
+	Published  uint64            `json:"published"`
+	Delivered  uint64            `json:"delivered"`
+	Failed     uint64            `json:"failed"`
+	Spooled    int               `json:"spooled"`
+	Parameters []parameterReport `json:"parameters"`
+	Errors     []errorReport     `json:"errors"`
+}
+
+// reportDevice is a device values were generated for.
+type reportDevice struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+// parameterReport is the summary of the values published for a parameter across every device.
+type parameterReport struct {
+	Name        string         `json:"name"`
+	DisplayName string         `json:"displayName"`
+	Published   uint64         `json:"published"`
+	Delivered   uint64         `json:"delivered"`
+	Failed      uint64         `json:"failed"`
+	Values      *summaryReport `json:"values,omitempty"`
+	Latency     *summaryReport `json:"latencyMs,omitempty"`
+}
+
+// summaryReport is the minimum, maximum and mean of a set of numbers.
+type summaryReport struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Mean float64 `json:"mean"`
+}
+
+// errorReport is the number of values that failed with an error.
+type errorReport struct {
+	Error string `json:"error"`
+	Count uint64 `json:"count"`
+}
+
+func (s summary) report() *summaryReport {
+	if s.count == 0 {
+		return nil
+	}
+	return &summaryReport{
+		Min:  round(s.min),
+		Max:  round(s.max),
+		Mean: round(s.sum / float64(s.count)),
+	}
+}
+
+// report summarises the run so far, parameters are in the order of the devices.
+func (s *runStats) report(devices []*aware.Device, spooled int) *runReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ended := time.Now()
+	out := &runReport{
+		Started:  s.started,
+		Ended:    ended,
+		Duration: round(ended.Sub(s.started).Seconds()),
+		Spooled:  spooled,
+		Errors:   []errorReport{},
+	}
+
+	seen := make(map[string]struct{})
+	for _, device := range devices {
+		out.Devices = append(out.Devices, reportDevice{ID: device.ID, DisplayName: device.DisplayName})
+
+		for _, parameter := range device.DeviceType.Parameters {
+			if _, ok := seen[parameter.Name]; ok {
+				continue
+			}
+			seen[parameter.Name] = struct{}{}
+
+			p, ok := s.parameters[parameter.Name]
+			if !ok {
+				p = &parameterStats{}
+			}
+
+			out.Parameters = append(out.Parameters, parameterReport{
+				Name:        parameter.Name,
+				DisplayName: parameter.DisplayName,
+				Published:   p.published,
+				Delivered:   p.published - p.failed,
+				Failed:      p.failed,
+				Values:      p.values.report(),
+				Latency:     p.latency.report(),
+			})
+			out.Published += p.published
+			out.Failed += p.failed
+		}
+	}
+	out.Delivered = out.Published - out.Failed
+
+	for msg, count := range s.errors {
+		out.Errors = append(out.Errors, errorReport{Error: msg, Count: count})
+	}
+	sort.Slice(out.Errors, func(i, j int) bool {
+		if out.Errors[i].Count != out.Errors[j].Count {
+			return out.Errors[i].Count > out.Errors[j].Count
+		}
+		return out.Errors[i].Error < out.Errors[j].Error
+	})
+
+	return out
+}
+
+// writeReport writes the report to the file as JSON.
+func writeReport(file string, r *runReport) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeSummary writes the report as a table for the terminal.
+func writeSummary(w io.Writer, r *runReport) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Duration\t%.1fs\n", r.Duration)
+	fmt.Fprintf(tw, "Devices\t%d\n", len(r.Devices))
+	fmt.Fprintf(tw, "Values\t%d published, %d delivered, %d failed\n", r.Published, r.Delivered, r.Failed)
+	if r.Spooled > 0 {
+		fmt.Fprintf(tw, "Spooled\t%d\n", r.Spooled)
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "Parameter\tPublished\tFailed\tMin\tMax\tMean\tLatency (mean ms)")
+	for _, p := range r.Parameters {
+		min, max, mean, latency := "-", "-", "-", "-"
+		if p.Values != nil {
+			min = fmt.Sprintf("%g", p.Values.Min)
+			max = fmt.Sprintf("%g", p.Values.Max)
+			mean = fmt.Sprintf("%g", p.Values.Mean)
+		}
+		if p.Latency != nil {
+			latency = fmt.Sprintf("%.1f", p.Latency.Mean)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\n", p.DisplayName, p.Published, p.Failed, min, max, mean, latency)
+	}
+
+	if len(r.Errors) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "Error\tValues")
+		for _, e := range r.Errors {
+			fmt.Fprintf(tw, "%s\t%d\n", e.Error, e.Count)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// round rounds to three decimal places so the report is readable.
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,67 @@
+package generate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+func TestRunStatsReport(t *testing.T) {
+	is := is.New(t)
+
+	devices := []*aware.Device{testDevice("DEV-1"), testDevice("DEV-2")}
+
+	s := newRunStats()
+	for i, v := range []float64{10, 20, 30} {
+		s.record(aware.DeliveryResult{
+			Value:   aware.TelemetryValue{DeviceID: devices[i%2].ID, ParameterName: "voltage", Value: v},
+			Latency: time.Duration(i+1) * time.Millisecond,
+		})
+	}
+	for i := 0; i < maxReportErrors+2; i++ {
+		s.record(aware.DeliveryResult{
+			Value: aware.TelemetryValue{DeviceID: "DEV-1", ParameterName: "broken", Value: "oops"},
+			Err:   fmt.Errorf("error %d", i),
+		})
+	}
+	s.record(aware.DeliveryResult{
+		Value: aware.TelemetryValue{DeviceID: "DEV-1", ParameterName: "broken", Value: "oops"},
+		Err:   errors.New("error 0"),
+	})
+
+	r := s.report(devices, 4)
+	is.Equal(len(r.Devices), 2)
+	is.Equal(r.Published, uint64(16))
+	is.Equal(r.Delivered, uint64(3))
+	is.Equal(r.Failed, uint64(13))
+	is.Equal(r.Spooled, 4)
+
+	// Parameters are shared by the devices so are only reported once
+	is.Equal(len(r.Parameters), 2)
+
+	voltage := r.Parameters[0]
+	is.Equal(voltage.Name, "voltage")
+	is.Equal(voltage.Published, uint64(3))
+	is.Equal(*voltage.Values, summaryReport{Min: 10, Max: 30, Mean: 20})
+	is.Equal(*voltage.Latency, summaryReport{Min: 1, Max: 3, Mean: 2})
+
+	broken := r.Parameters[1]
+	is.Equal(broken.Failed, uint64(13))
+	is.Equal(broken.Values, nil) // Strings have no statistics
+
+	// The most common error is first and the rest past the limit are grouped
+	is.Equal(len(r.Errors), maxReportErrors+1)
+	is.Equal(r.Errors[0], errorReport{Error: "error 0", Count: 2})
+	is.Equal(r.Errors[1], errorReport{Error: otherErrors, Count: 2})
+
+	var b bytes.Buffer
+	is.NoErr(writeSummary(&b, r))
+	is.True(strings.Contains(b.String(), "16 published, 3 delivered, 13 failed"))
+	is.True(strings.Contains(b.String(), "Voltage"))
+}
@@ -0,0 +1,341 @@
+package generate
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+)
+
+// valueTypePrefix marks a schedule key as a value type rather than a parameter name.
+const valueTypePrefix = "type:"
+
+// schedule decides when values are next published.
+type schedule interface {
+	// next returns the first time after the given time, or the zero time if there is none.
+	next(after time.Time) time.Time
+}
+
+// timing is a schedule with a random delay of up to jitter added to every time.
+type timing struct {
+	spec     string
+	schedule schedule
+	jitter   time.Duration
+}
+
+// at returns the time to publish values that are due at the given time.
+func (t timing) at(due time.Time) time.Time {
+	if t.jitter <= 0 {
+		return due
+	}
+	return due.Add(time.Duration(rand.Int63n(int64(t.jitter))))
+}
+
+// parseTiming parses a schedule, optionally followed by "~" and a jitter duration.
+//
+// Schedules are either an interval such as "1s" or "@every 15m", a cron expression
+// with five fields (minute, hour, day of month, month, day of week) or six fields
+// with seconds first, or one of @yearly, @monthly, @weekly, @daily and @hourly.
+func parseTiming(spec string) (timing, error) {
+	t := timing{spec: strings.TrimSpace(spec)}
+
+	expr := t.spec
+	if i := strings.LastIndex(expr, "~"); i >= 0 {
+		jitter, err := time.ParseDuration(strings.TrimSpace(expr[i+1:]))
+		if err != nil || jitter < 0 {
+			return timing{}, fmt.Errorf("invalid jitter in schedule %q", spec)
+		}
+		t.jitter = jitter
+		expr = strings.TrimSpace(expr[:i])
+	}
+
+	s, err := parseSchedule(expr)
+	if err != nil {
+		return timing{}, err
+	}
+	t.schedule = s
+
+	return t, nil
+}
+
+func parseSchedule(expr string) (schedule, error) {
+	switch expr {
+	case "":
+		return nil, fmt.Errorf("empty schedule")
+	case "@yearly", "@annually":
+		expr = "0 0 1 1 *"
+	case "@monthly":
+		expr = "0 0 1 * *"
+	case "@weekly":
+		expr = "0 0 * * 0"
+	case "@daily", "@midnight":
+		expr = "0 0 * * *"
+	case "@hourly":
+		expr = "0 * * * *"
+	}
+
+	if strings.HasPrefix(expr, "@every ") {
+		return parseInterval(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+	}
+	if !strings.ContainsAny(expr, " \t") {
+		return parseInterval(expr)
+	}
+
+	return parseCron(expr)
+}
+
+// interval publishes values at a fixed period.
+type interval time.Duration
+
+func parseInterval(s string) (schedule, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q, expected a duration or cron expression", s)
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("schedule interval %q must be greater than zero", s)
+	}
+	return interval(d), nil
+}
+
+func (i interval) next(after time.Time) time.Time {
+	return after.Add(time.Duration(i))
+}
+
+// cron publishes values at the times matching a cron expression, each field is a
+// set of the values it matches.
+type cron struct {
+	second, minute, hour, dom, month, dow uint64
+
+	// When both days are restricted a time matching either is used, as with cron
+	domAny, dowAny bool
+}
+
+// cronField is the range of values a cron field accepts.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"second", 0, 59},
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+func parseCron(expr string) (schedule, error) {
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron expression %q, expected 5 or 6 fields", expr)
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+
+	// Sunday can be 0 or 7
+	dow := sets[5]
+	if dow&(1<<7) != 0 {
+		dow = dow&^(1<<7) | 1
+	}
+
+	return &cron{
+		second: sets[0],
+		minute: sets[1],
+		hour:   sets[2],
+		dom:    sets[3],
+		month:  sets[4],
+		dow:    dow,
+		domAny: fields[3] == "*" || fields[3] == "?",
+		dowAny: fields[5] == "*" || fields[5] == "?",
+	}, nil
+}
+
+// parseCronField parses a comma separated list of "*", values and ranges, each
+// optionally followed by "/" and a step.
+func parseCronField(s string, field cronField) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(s, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %s %q", field.name, part)
+			}
+			rng, step = part[:i], n
+		}
+
+		low, high := field.min, field.max
+		switch {
+		case rng == "*" || rng == "?":
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if low, err = cronValue(bounds[0], field); err != nil {
+				return 0, err
+			}
+			if high, err = cronValue(bounds[1], field); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range in %s %q", field.name, part)
+			}
+		default:
+			v, err := cronValue(rng, field)
+			if err != nil {
+				return 0, err
+			}
+			low = v
+			if step == 1 {
+				high = v
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+func cronValue(s string, field cronField) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("%s %q must be between %d and %d", field.name, s, field.min, field.max)
+	}
+	return v, nil
+}
+
+func (c *cron) next(after time.Time) time.Time {
+	// Nothing matches an impossible date such as the 31st of February within a few years
+	const searchYears = 5
+
+	loc := after.Location()
+	t := after.Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + searchYears
+
+	for t.Year() <= limit {
+		switch {
+		case !has(c.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(c.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(c.minute, t.Minute()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+		case !has(c.second, t.Second()):
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (c *cron) matchesDay(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+// schedules picks the timing of each parameter. A schedule for the parameter name
+// or display name is used first, then one for its value type, then the fallback.
+type schedules struct {
+	fallback timing
+	byKey    map[string]timing
+}
+
+// own returns the schedule given for the parameter or its value type, if any.
+func (s schedules) own(parameter aware.DeviceTypeParameter) (timing, bool) {
+	for _, key := range []string{parameter.Name, parameter.DisplayName, valueTypePrefix + string(parameter.ValueType)} {
+		if t, ok := s.byKey[key]; ok {
+			return t, true
+		}
+	}
+	return timing{}, false
+}
+
+// parseSchedules parses schedules given as KEY=SCHEDULE, where the key is a
+// parameter name, display name or "type:" followed by a value type.
+func parseSchedules(values []string) (map[string]timing, error) {
+	out := make(map[string]timing, len(values))
+	for _, value := range values {
+		key, spec, ok := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid schedule %q, expected PARAMETER=SCHEDULE", value)
+		}
+
+		t, err := parseTiming(spec)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = t
+	}
+	return out, nil
+}
+
+// job is a set of parameters of a device that are published together.
+type job struct {
+	device     *aware.Device
+	parameters []int // Indexes of the device type parameters
+	timing     timing
+	fallback   bool          // Whether the job is on the fallback schedule
+	due        time.Time     // When the schedule is next due
+	at         time.Time     // When the values are next published, including jitter
+	busy       chan struct{} // Full while values are being published
+}
+
+// nextDue returns the next time the job is due after the last, skipping any times
+// that have already passed.
+func (j *job) nextDue(now time.Time) time.Time {
+	due := j.timing.schedule.next(j.due)
+	if !due.IsZero() && due.Before(now) {
+		due = j.timing.schedule.next(now)
+	}
+	return due
+}
+
+// jobQueue is a heap of jobs ordered by when they are next published.
+type jobQueue []*job
+
+func (q jobQueue) Len() int           { return len(q) }
+func (q jobQueue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+func (q jobQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *jobQueue) Push(x interface{}) {
+	*q = append(*q, x.(*job))
+}
+
+func (q *jobQueue) Pop() interface{} {
+	old := *q
+	j := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return j
+}
@@ -0,0 +1,104 @@
+package generate
+
+import (
+	"testing"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+func TestParseTiming(t *testing.T) {
+	is := is.New(t)
+
+	after := time.Date(2024, time.March, 14, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec   string
+		next   time.Time
+		jitter time.Duration
+	}{
+		{"15m", after.Add(15 * time.Minute), 0},
+		{"@every 1s ~ 500ms", after.Add(time.Second), 500 * time.Millisecond},
+		{"*/15 * * * *", time.Date(2024, time.March, 14, 10, 15, 0, 0, time.UTC), 0},
+		{"0,30 9-17 * * 1-5", time.Date(2024, time.March, 14, 10, 30, 0, 0, time.UTC), 0},
+		{"*/10 * * * * *", time.Date(2024, time.March, 14, 10, 7, 40, 0, time.UTC), 0},
+		{"@daily~1m", time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), time.Minute},
+		{"0 0 * * 7", time.Date(2024, time.March, 17, 0, 0, 0, 0, time.UTC), 0},
+		{"0 0 1 * 6", time.Date(2024, time.March, 16, 0, 0, 0, 0, time.UTC), 0},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC), 0},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTiming(tt.spec)
+		is.NoErr(err)
+		is.Equal(got.jitter, tt.jitter)
+		is.Equal(got.schedule.next(after), tt.next) // tt.spec
+	}
+
+	// The 31st of February never comes
+	impossible, err := parseTiming("0 0 31 2 *")
+	is.NoErr(err)
+	is.True(impossible.schedule.next(after).IsZero())
+
+	for _, spec := range []string{"", "0s", "soon", "* * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "1m~later"} {
+		_, err := parseTiming(spec)
+		is.True(err != nil) // spec should be invalid
+	}
+}
+
+func TestSchedulesOwn(t *testing.T) {
+	is := is.New(t)
+
+	byKey, err := parseSchedules([]string{"energy=*/15 * * * *", "Current=1s", "type:float=5s"})
+	is.NoErr(err)
+
+	s := schedules{fallback: timing{spec: "30s"}, byKey: byKey}
+
+	tests := []struct {
+		parameter aware.DeviceTypeParameter
+		spec      string
+		own       bool
+	}{
+		{aware.DeviceTypeParameter{Name: "energy", ValueType: aware.Float}, "*/15 * * * *", true},
+		{aware.DeviceTypeParameter{Name: "current", DisplayName: "Current", ValueType: aware.Float}, "1s", true},
+		{aware.DeviceTypeParameter{Name: "voltage", ValueType: aware.Float}, "5s", true},
+		{aware.DeviceTypeParameter{Name: "status", ValueType: aware.String}, "", false}, // Left on the fallback
+	}
+
+	for _, tt := range tests {
+		got, ok := s.own(tt.parameter)
+		is.Equal(ok, tt.own)        // tt.parameter.Name
+		is.Equal(got.spec, tt.spec) // tt.parameter.Name
+	}
+
+	_, err = parseSchedules([]string{"energy"})
+	is.True(err != nil)
+}
+
+func TestGeneratorJobs(t *testing.T) {
+	is := is.New(t)
+
+	byKey, err := parseSchedules([]string{"Voltage=1s"})
+	is.NoErr(err)
+
+	device := testDevice("DEV-1")
+	g := newGenerator(aware.NewClient(aware.Config{}), []*aware.Device{device}, &generateParams{
+		frequency:   time.Hour,
+		concurrency: 1,
+		schedules:   byKey,
+	}, nil, nil)
+	defer func() { _ = g.close() }()
+
+	now := time.Date(2024, time.March, 14, 10, 7, 30, 0, time.UTC)
+	queue := g.jobs(now)
+	is.Equal(len(queue), 2)
+
+	// Voltage has its own schedule by display name, broken is left on the fallback
+	is.Equal(queue[0].parameters, []int{0})
+	is.Equal(queue[0].timing.spec, "1s")
+	is.Equal(queue[0].due, now.Add(time.Second))
+	is.True(queue[1].fallback)
+	is.Equal(queue[1].parameters, []int{1})
+	is.Equal(queue[1].timing.spec, "1h0m0s")
+}
@@ -0,0 +1,317 @@
+// Package imports contains the command for importing device telemetry from a file.
+package imports
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type importParams struct {
+	ID          string
+	file        string
+	format      string
+	timeColumn  string
+	overrides   map[string]string
+	shiftToNow  bool
+	shift       time.Duration
+	speed       float64
+	skipInvalid bool
+	dryRun      bool
+	batchSize   int
+}
+
+type importCmd struct {
+	client  *aware.Client
+	params  *importParams
+	device  *aware.Device
+	mapping *mapping
+	records []*record
+	offset  time.Duration
+}
+
+// NewCmdDeviceTelemetryImport is the command for importing device telemetry.
+func NewCmdDeviceTelemetryImport() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import ID",
+		Short: "Publish telemetry for a device from a CSV or NDJSON file",
+		Long: `Publish telemetry for a device from a CSV or NDJSON file.
+
+Each row is one point in time, with a timestamp column and a column for each
+parameter. Columns are matched to parameters by name or display name, use
+--map to match any that are named differently.
+
+By default rows are published as fast as possible with their original
+timestamps. Use --shift-to-now to move the data so the first row is now and
+--speed 1 to publish it in real-time.`,
+		Example: `aware device telemetry import 5d1d574439d157849090ea6a --file data.csv
+aware device telemetry import 5d1d574439d157849090ea6a --file data.ndjson --shift-to-now --speed 10
+aware device telemetry import 5d1d574439d157849090ea6a --file data.csv --map "Ohms=pilot-forward-resistance"`,
+		Args: cobra.ExactArgs(1),
+		Run:  importTelemetry,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("file", "f", "", "CSV or NDJSON file to import")
+	cmd.Flags().String("format", "", "Format of the file, csv or ndjson (default is from the file extension)")
+	cmd.Flags().String("time-column", "", "Column containing the timestamp (default is timestamp, time or date)")
+	cmd.Flags().StringToString("map", nil, "Map a column to a parameter name, as column=parameter")
+	cmd.Flags().Bool("shift-to-now", false, "Shift the timestamps so the first row is published as now")
+	cmd.Flags().Duration("shift", 0, "Shift the timestamps by a fixed duration")
+	cmd.Flags().Float64("speed", 0, "Playback speed, 1 is real-time and 0 is as fast as possible")
+	cmd.Flags().Bool("skip-invalid", false, "Skip rows that fail validation instead of stopping")
+	cmd.Flags().Bool("dry-run", false, "Validate the file and show the mapping without publishing")
+	cmd.Flags().Int("batch-size", 100, "Maximum number of values sent to AWARE in one request")
+
+	_ = cmd.MarkFlagRequired("file")
+}
+
+func importTelemetry(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	ic := importCmd{
+		client: client,
+		params: params,
+	}
+
+	utils.ExitIfError(ic.setDevice())
+	utils.ExitIfError(ic.setRecords())
+
+	if len(ic.records) == 0 {
+		utils.Failed("No rows to import")
+	}
+
+	ic.printSummary()
+
+	if params.dryRun {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+	go func() {
+		select {
+		case <-signalChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	rows, delivered, failed := ic.run(ctx)
+
+	if ctx.Err() != nil {
+		fmt.Println()
+		utils.Warn("Import interrupted after %d of %d rows, published %d values and %d failed", rows, len(ic.records), delivered, failed)
+		os.Exit(1)
+	}
+
+	if failed > 0 {
+		utils.Failed("Published %d values, %d failed", delivered, failed)
+	}
+
+	utils.Success("Published %d values from %d rows", delivered, rows)
+}
+
+func (i *importCmd) setDevice() error {
+	s := utils.ShowLoading(fmt.Sprintf("Fetching Device %s", i.params.ID))
+	defer s.Stop()
+
+	device, err := i.client.GetDeviceByID(i.params.ID)
+	if err != nil {
+		return err
+	}
+
+	i.device = device
+	return nil
+}
+
+func (i *importCmd) setRecords() error {
+	format := i.params.format
+	if format == "" {
+		detected, err := detectFormat(i.params.file)
+		if err != nil {
+			return err
+		}
+		format = detected
+	}
+
+	m, records, invalid, err := readFile(i.params.file, format, i.device.DeviceType.Parameters, i.params.timeColumn, i.params.overrides)
+	if err != nil {
+		return err
+	}
+
+	if len(invalid) > 0 {
+		const maxShown = 10
+		for n, err := range invalid {
+			if n == maxShown {
+				utils.Fail("... and %d more", len(invalid)-maxShown)
+				break
+			}
+			utils.Fail("%s", err)
+		}
+
+		if !i.params.skipInvalid {
+			return fmt.Errorf("%d invalid rows, fix them or use --skip-invalid", len(invalid))
+		}
+		utils.Warn("Skipping %d invalid rows", len(invalid))
+	}
+
+	i.mapping = m
+	i.records = records
+
+	i.offset = i.params.shift
+	if i.params.shiftToNow && len(records) > 0 {
+		i.offset += time.Since(records[0].timestamp)
+	}
+
+	return nil
+}
+
+func (i *importCmd) printSummary() {
+	first := i.records[0].timestamp.Add(i.offset)
+	last := i.records[len(i.records)-1].timestamp.Add(i.offset)
+
+	fmt.Fprintf(os.Stderr, "Device:  %s (%s)\n", i.device.DisplayName, i.device.ID)
+	fmt.Fprintf(os.Stderr, "Rows:    %d\n", len(i.records))
+	fmt.Fprintf(os.Stderr, "Range:   %s -> %s\n", first.Format(time.RFC3339), last.Format(time.RFC3339))
+	if i.params.speed > 0 {
+		duration := time.Duration(float64(last.Sub(first)) / i.params.speed)
+		fmt.Fprintf(os.Stderr, "Runtime: %s at %gx\n", duration.Round(time.Second), i.params.speed)
+	}
+	fmt.Fprintln(os.Stderr)
+
+	fmt.Fprintf(os.Stderr, "%s -> timestamp\n", i.mapping.timeColumn)
+	for _, column := range i.mapping.columns() {
+		parameter := i.mapping.parameters[column]
+		fmt.Fprintf(os.Stderr, "%s -> %s (%s)\n", column, parameter.Name, parameter.ValueType)
+	}
+	if len(i.mapping.unmapped) > 0 {
+		utils.Warn("Ignoring unmapped columns: %s", strings.Join(i.mapping.unmapped, ", "))
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+// run publishes each record once it is due, based on the playback speed, and returns
+// the number of records processed. Values that couldn't be queued for publishing are
+// counted as failed.
+func (i *importCmd) run(ctx context.Context) (rows int, delivered, failed uint64) {
+	bar := utils.ShowProgress("Importing", len(i.records))
+
+	publisher := i.client.NewPublisher(aware.PublisherConfig{
+		BatchSize: i.params.batchSize,
+	})
+
+	start := time.Now()
+	first := i.records[0].timestamp
+
+	for _, rec := range i.records {
+		if i.params.speed > 0 {
+			due := start.Add(time.Duration(float64(rec.timestamp.Sub(first)) / i.params.speed))
+			select {
+			case <-time.After(time.Until(due)):
+			case <-ctx.Done():
+			}
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		for name, value := range rec.values {
+			err := publisher.Publish(ctx, aware.TelemetryValue{
+				DeviceID:      i.device.ID,
+				ParameterName: name,
+				Value:         value,
+				Timestamp:     rec.timestamp.Add(i.offset),
+			})
+			if err != nil {
+				failed++
+			}
+		}
+		rows++
+		bar.Increment(1)
+	}
+
+	_ = publisher.Close()
+	bar.Stop()
+
+	stats := publisher.Stats()
+	return rows, stats.Delivered, stats.Failed + failed
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *importParams {
+	file, err := cmd.Flags().GetString("file")
+	utils.ExitIfError(err)
+
+	format, err := cmd.Flags().GetString("format")
+	utils.ExitIfError(err)
+
+	format = strings.ToLower(format)
+	if format != "" && format != formatCSV && format != formatNDJSON {
+		utils.Failed("--format must be csv or ndjson")
+	}
+
+	timeColumn, err := cmd.Flags().GetString("time-column")
+	utils.ExitIfError(err)
+
+	overrides, err := cmd.Flags().GetStringToString("map")
+	utils.ExitIfError(err)
+
+	shiftToNow, err := cmd.Flags().GetBool("shift-to-now")
+	utils.ExitIfError(err)
+
+	shift, err := cmd.Flags().GetDuration("shift")
+	utils.ExitIfError(err)
+
+	speed, err := cmd.Flags().GetFloat64("speed")
+	utils.ExitIfError(err)
+
+	if speed < 0 {
+		utils.Failed("--speed can't be negative")
+	}
+
+	skipInvalid, err := cmd.Flags().GetBool("skip-invalid")
+	utils.ExitIfError(err)
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	utils.ExitIfError(err)
+
+	batchSize, err := cmd.Flags().GetInt("batch-size")
+	utils.ExitIfError(err)
+
+	return &importParams{
+		ID:          args[0],
+		file:        file,
+		format:      format,
+		timeColumn:  timeColumn,
+		overrides:   overrides,
+		shiftToNow:  shiftToNow,
+		shift:       shift,
+		speed:       speed,
+		skipInvalid: skipInvalid,
+		dryRun:      dryRun,
+		batchSize:   batchSize,
+	}
+}
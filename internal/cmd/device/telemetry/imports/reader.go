@@ -0,0 +1,367 @@
+package imports
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+)
+
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// timeColumns are the column names used for the timestamp when one isn't given.
+var timeColumns = []string{"timestamp", "time", "date", "datetime", "ts"}
+
+// record is every value from one row of the file.
+type record struct {
+	line      int
+	timestamp time.Time
+	values    map[string]interface{} // Keyed by parameter name
+}
+
+// mapping links the columns of the file to device type parameters.
+type mapping struct {
+	timeColumn string
+	parameters map[string]*aware.DeviceTypeParameter // Keyed by column
+	unmapped   []string
+}
+
+// newMapping maps every column to a parameter by name or display name, ignoring case.
+// Overrides are column=parameter pairs that take precedence.
+func newMapping(columns []string, parameters []aware.DeviceTypeParameter, timeColumn string, overrides map[string]string) (*mapping, error) {
+	m := &mapping{
+		timeColumn: timeColumn,
+		parameters: make(map[string]*aware.DeviceTypeParameter),
+	}
+
+	lookup := make(map[string]*aware.DeviceTypeParameter, len(parameters)*2)
+	for i := range parameters {
+		lookup[strings.ToLower(parameters[i].Name)] = &parameters[i]
+		lookup[strings.ToLower(parameters[i].DisplayName)] = &parameters[i]
+	}
+
+	if m.timeColumn == "" {
+		for _, column := range columns {
+			for _, name := range timeColumns {
+				if strings.EqualFold(column, name) && m.timeColumn == "" {
+					m.timeColumn = column
+				}
+			}
+		}
+	}
+
+	if m.timeColumn == "" {
+		return nil, fmt.Errorf("no timestamp column found, set one with --time-column")
+	}
+
+	found := false
+	for _, column := range columns {
+		if column == m.timeColumn {
+			found = true
+			continue
+		}
+
+		name, ok := overrides[column]
+		if !ok {
+			name = column
+		}
+
+		parameter, ok := lookup[strings.ToLower(name)]
+		if !ok {
+			if _, overridden := overrides[column]; overridden {
+				return nil, fmt.Errorf("column %s is mapped to %s which isn't a parameter of the device", column, name)
+			}
+			m.unmapped = append(m.unmapped, column)
+			continue
+		}
+		m.parameters[column] = parameter
+	}
+
+	if !found {
+		return nil, fmt.Errorf("timestamp column %s not found", m.timeColumn)
+	}
+
+	if len(m.parameters) == 0 {
+		return nil, fmt.Errorf("none of the columns match a parameter of the device")
+	}
+
+	return m, nil
+}
+
+// columns returns the mapped columns in a stable order.
+func (m *mapping) columns() []string {
+	out := make([]string, 0, len(m.parameters))
+	for column := range m.parameters {
+		out = append(out, column)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// detectFormat works out the file format from the extension.
+func detectFormat(file string) (string, error) {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".csv":
+		return formatCSV, nil
+	case ".ndjson", ".jsonl", ".json":
+		return formatNDJSON, nil
+	}
+	return "", fmt.Errorf("unable to detect the format of %s, set one with --format", file)
+}
+
+// readFile reads every row of the file into records, sorted by timestamp.
+// Rows that can't be read or fail validation are returned as errors.
+func readFile(file, format string, parameters []aware.DeviceTypeParameter, timeColumn string, overrides map[string]string) (*mapping, []*record, []error, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var (
+		m       *mapping
+		records []*record
+		invalid []error
+	)
+
+	switch format {
+	case formatCSV:
+		m, records, invalid, err = readCSV(f, parameters, timeColumn, overrides)
+	case formatNDJSON:
+		m, records, invalid, err = readNDJSON(f, parameters, timeColumn, overrides)
+	default:
+		err = fmt.Errorf("unknown format %s", format)
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].timestamp.Before(records[j].timestamp)
+	})
+
+	return m, records, invalid, nil
+}
+
+func readCSV(r io.Reader, parameters []aware.DeviceTypeParameter, timeColumn string, overrides map[string]string) (*mapping, []*record, []error, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to read header: %w", err)
+	}
+
+	m, err := newMapping(header, parameters, timeColumn, overrides)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var (
+		records []*record
+		invalid []error
+	)
+
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			invalid = append(invalid, err)
+			continue
+		}
+
+		raw := make(map[string]interface{}, len(row))
+		for i, value := range row {
+			if i < len(header) && value != "" {
+				raw[header[i]] = value
+			}
+		}
+
+		rec, err := m.record(line, raw)
+		if err != nil {
+			invalid = append(invalid, err)
+			continue
+		}
+		records = append(records, rec)
+	}
+
+	return m, records, invalid, nil
+}
+
+func readNDJSON(r io.Reader, parameters []aware.DeviceTypeParameter, timeColumn string, overrides map[string]string) (*mapping, []*record, []error, error) {
+	const maxLineSize = 16 * 1024 * 1024
+
+	var (
+		m       *mapping
+		records []*record
+		invalid []error
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var raw map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &raw); err != nil {
+			invalid = append(invalid, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+
+		// The first object decides the mapping
+		if m == nil {
+			columns := make([]string, 0, len(raw))
+			for column := range raw {
+				columns = append(columns, column)
+			}
+			sort.Strings(columns)
+
+			var err error
+			m, err = newMapping(columns, parameters, timeColumn, overrides)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+
+		rec, err := m.record(line, raw)
+		if err != nil {
+			invalid = append(invalid, err)
+			continue
+		}
+		records = append(records, rec)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if m == nil {
+		return nil, nil, nil, fmt.Errorf("file is empty")
+	}
+
+	return m, records, invalid, nil
+}
+
+// record converts a row into a record, validating each value against its parameter's value type.
+func (m *mapping) record(line int, raw map[string]interface{}) (*record, error) {
+	rawTime, ok := raw[m.timeColumn]
+	if !ok {
+		return nil, fmt.Errorf("line %d: missing timestamp", line)
+	}
+
+	ts, err := parseTimestamp(rawTime)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", line, err)
+	}
+
+	rec := &record{
+		line:      line,
+		timestamp: ts,
+		values:    make(map[string]interface{}, len(m.parameters)),
+	}
+
+	for column, parameter := range m.parameters {
+		value, ok := raw[column]
+		if !ok || value == nil {
+			continue
+		}
+
+		converted, err := convertValue(value, parameter.ValueType)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: column %s: %w", line, column, err)
+		}
+		rec.values[parameter.Name] = converted
+	}
+
+	return rec, nil
+}
+
+// convertValue converts the value read from the file into the parameter's value type.
+func convertValue(value interface{}, valueType aware.DeviceTypeParameterValueType) (interface{}, error) {
+	s, isString := value.(string)
+
+	switch valueType {
+	case aware.Float:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a float", v)
+			}
+			return f, nil
+		}
+	case aware.Bool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a bool", v)
+			}
+			return b, nil
+		}
+	case aware.String:
+		if isString {
+			return s, nil
+		}
+		return fmt.Sprintf("%v", value), nil
+	case aware.Object, aware.Waveform, aware.Spectrum:
+		if !isString {
+			return value, nil
+		}
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, fmt.Errorf("%s value is not valid JSON", valueType)
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("%v is not a %s", value, valueType)
+}
+
+// parseTimestamp accepts RFC3339, common date time layouts and unix seconds or milliseconds.
+func parseTimestamp(value interface{}) (time.Time, error) {
+	const unixMillisThreshold = 1e11
+
+	switch v := value.(type) {
+	case float64:
+		if v > unixMillisThreshold {
+			return time.UnixMilli(int64(v)), nil
+		}
+		return time.Unix(int64(v), 0), nil
+	case string:
+		v = strings.TrimSpace(v)
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"} {
+			if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+				return t, nil
+			}
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return parseTimestamp(f)
+		}
+		return time.Time{}, fmt.Errorf("unable to parse timestamp %q", v)
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse timestamp %v", value)
+}
@@ -0,0 +1,64 @@
+package imports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+var testParameters = []aware.DeviceTypeParameter{
+	{Name: "voltage", DisplayName: "Voltage", ValueType: aware.Float},
+	{Name: "relay-closed", DisplayName: "Relay Closed", ValueType: aware.Bool},
+}
+
+func TestReadCSV(t *testing.T) {
+	is := is.New(t)
+
+	data := `Time,Voltage,Closed,Notes
+2026-01-01T00:01:00Z,240.5,true,second
+2026-01-01T00:00:00Z,239.8,false,first
+2026-01-01T00:02:00Z,abc,true,invalid
+`
+
+	m, records, invalid, err := readCSV(strings.NewReader(data), testParameters, "", map[string]string{"Closed": "relay-closed"})
+	is.NoErr(err)
+
+	is.Equal(m.timeColumn, "Time")
+	is.Equal(m.columns(), []string{"Closed", "Voltage"})
+	is.Equal(m.unmapped, []string{"Notes"})
+
+	is.Equal(len(invalid), 1)
+	is.True(strings.Contains(invalid[0].Error(), "line 4"))
+
+	is.Equal(len(records), 2)
+	is.Equal(records[1].values["voltage"], 239.8)
+	is.Equal(records[1].values["relay-closed"], false)
+}
+
+func TestReadNDJSON(t *testing.T) {
+	is := is.New(t)
+
+	data := `{"ts": 1767225600, "voltage": 240.5}
+
+{"ts": "1767225660000", "voltage": "241"}
+`
+
+	_, records, invalid, err := readNDJSON(strings.NewReader(data), testParameters, "", nil)
+	is.NoErr(err)
+	is.Equal(len(invalid), 0)
+	is.Equal(len(records), 2)
+
+	is.True(records[0].timestamp.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	is.True(records[1].timestamp.Equal(time.Date(2026, 1, 1, 0, 1, 0, 0, time.UTC)))
+	is.Equal(records[1].values["voltage"], 241.0)
+}
+
+func TestNewMappingUnknownOverride(t *testing.T) {
+	is := is.New(t)
+
+	_, err := newMapping([]string{"timestamp", "v"}, testParameters, "", map[string]string{"v": "current"})
+	is.True(err != nil)
+}
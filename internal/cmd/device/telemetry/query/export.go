@@ -0,0 +1,186 @@
+package query
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+)
+
+const (
+	formatTable    = "table"
+	formatCSV      = "csv"
+	formatNDJSON   = "ndjson"
+	formatColumnar = "columnar"
+)
+
+var formats = []string{formatTable, formatCSV, formatNDJSON, formatColumnar}
+
+// series is the telemetry of a device pivoted into a row for each timestamp,
+// with a value for each parameter.
+type series struct {
+	parameters []aware.DeviceTypeParameter
+	rows       map[int64]*seriesRow // Keyed by unix nanoseconds
+}
+
+type seriesRow struct {
+	timestamp time.Time
+	values    map[string]interface{} // Keyed by parameter name
+}
+
+func newSeries(parameters []aware.DeviceTypeParameter) *series {
+	return &series{
+		parameters: parameters,
+		rows:       make(map[int64]*seriesRow),
+	}
+}
+
+// add adds the values to the rows for their timestamps, values for unknown parameters are ignored.
+func (s *series) add(values []*aware.TelemetryValue) {
+	for _, v := range values {
+		key := v.Timestamp.UnixNano()
+		row, ok := s.rows[key]
+		if !ok {
+			row = &seriesRow{timestamp: v.Timestamp, values: make(map[string]interface{})}
+			s.rows[key] = row
+		}
+		row.values[v.ParameterName] = v.Value
+	}
+}
+
+// sorted returns the rows in timestamp order, with the values in parameter order.
+func (s *series) sorted() ([]time.Time, [][]interface{}) {
+	keys := make([]int64, 0, len(s.rows))
+	for key := range s.rows {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	timestamps := make([]time.Time, 0, len(keys))
+	values := make([][]interface{}, 0, len(keys))
+	for _, key := range keys {
+		row := s.rows[key]
+
+		rowValues := make([]interface{}, len(s.parameters))
+		for i, parameter := range s.parameters {
+			rowValues[i] = row.values[parameter.Name]
+		}
+
+		timestamps = append(timestamps, row.timestamp)
+		values = append(values, rowValues)
+	}
+
+	return timestamps, values
+}
+
+// writeCSV writes a header of timestamp followed by the parameter names, then a row for each timestamp.
+// This matches the layout read by the import command.
+func writeCSV(w io.Writer, s *series) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"timestamp"}
+	for _, parameter := range s.parameters {
+		header = append(header, parameter.Name)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	timestamps, values := s.sorted()
+	for i, ts := range timestamps {
+		record := []string{ts.Format(time.RFC3339Nano)}
+		for _, value := range values[i] {
+			cell, err := formatCell(value)
+			if err != nil {
+				return err
+			}
+			record = append(record, cell)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeNDJSON writes each value as a JSON object on its own line.
+func writeNDJSON(w io.Writer, values []*aware.TelemetryValue) error {
+	enc := json.NewEncoder(w)
+	for _, v := range values {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnarFile stores the values of each parameter together, similar to the layout of a Parquet file.
+type columnarFile struct {
+	Device  string           `json:"device"`
+	Rows    int              `json:"rows"`
+	Columns []columnarColumn `json:"columns"`
+}
+
+type columnarColumn struct {
+	Name   string        `json:"name"`
+	Type   string        `json:"type"`
+	Unit   string        `json:"unit,omitempty"`
+	Values []interface{} `json:"values"`
+}
+
+// writeColumnar writes a JSON file with a column for the timestamps and each parameter.
+func writeColumnar(w io.Writer, deviceID string, s *series) error {
+	timestamps, values := s.sorted()
+
+	file := columnarFile{
+		Device:  deviceID,
+		Rows:    len(timestamps),
+		Columns: make([]columnarColumn, 0, len(s.parameters)+1),
+	}
+
+	tsColumn := columnarColumn{Name: "timestamp", Type: "timestamp", Values: make([]interface{}, len(timestamps))}
+	for i, ts := range timestamps {
+		tsColumn.Values[i] = ts.Format(time.RFC3339Nano)
+	}
+	file.Columns = append(file.Columns, tsColumn)
+
+	for p, parameter := range s.parameters {
+		column := columnarColumn{
+			Name:   parameter.Name,
+			Type:   string(parameter.ValueType),
+			Unit:   parameter.Display.Unit,
+			Values: make([]interface{}, len(values)),
+		}
+		for i := range values {
+			column.Values[i] = values[i][p]
+		}
+		file.Columns = append(file.Columns, column)
+	}
+
+	enc := json.NewEncoder(w)
+	return enc.Encode(&file)
+}
+
+// formatCell formats a value for a CSV cell, objects are written as JSON.
+func formatCell(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64, bool:
+		return fmt.Sprintf("%v", v), nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
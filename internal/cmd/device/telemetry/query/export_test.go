@@ -0,0 +1,56 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+var testParameters = []aware.DeviceTypeParameter{
+	{Name: "voltage", ValueType: aware.Float, Display: aware.DeviceTypeParameterDisplay{Unit: "V"}},
+	{Name: "relay-closed", ValueType: aware.Bool},
+}
+
+func testSeries() *series {
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s := newSeries(testParameters)
+	s.add([]*aware.TelemetryValue{
+		{DeviceID: "DEV-1", ParameterName: "voltage", Value: 241.0, Timestamp: ts.Add(time.Minute)},
+		{DeviceID: "DEV-1", ParameterName: "voltage", Value: 240.5, Timestamp: ts},
+		{DeviceID: "DEV-1", ParameterName: "relay-closed", Value: true, Timestamp: ts},
+	})
+	return s
+}
+
+func TestWriteCSV(t *testing.T) {
+	is := is.New(t)
+
+	var buf bytes.Buffer
+	is.NoErr(writeCSV(&buf, testSeries()))
+
+	is.Equal(buf.String(), "timestamp,voltage,relay-closed\n"+
+		"2026-01-01T00:00:00Z,240.5,true\n"+
+		"2026-01-01T00:01:00Z,241,\n")
+}
+
+func TestWriteColumnar(t *testing.T) {
+	is := is.New(t)
+
+	var buf bytes.Buffer
+	is.NoErr(writeColumnar(&buf, "DEV-1", testSeries()))
+
+	var file columnarFile
+	is.NoErr(json.Unmarshal(buf.Bytes(), &file))
+
+	is.Equal(file.Rows, 2)
+	is.Equal(len(file.Columns), 3)
+	is.Equal(file.Columns[1].Name, "voltage")
+	is.Equal(file.Columns[1].Unit, "V")
+	is.Equal(file.Columns[1].Values, []interface{}{240.5, 241.0})
+	is.Equal(file.Columns[2].Values, []interface{}{true, nil})
+}
@@ -0,0 +1,354 @@
+// Package query contains the command for querying historical device telemetry.
+package query
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const dateLayout = "2006-01-02"
+
+type queryParams struct {
+	ID          string
+	from        time.Time
+	to          time.Time
+	parameters  []string
+	aggregation aware.TelemetryAggregation
+	bucket      time.Duration
+	format      string
+	output      string
+	pageSize    int
+	plain       bool
+	noHeaders   bool
+}
+
+type queryCmd struct {
+	client *aware.Client
+	params *queryParams
+	device *aware.Device
+	series *series
+	count  int
+}
+
+// NewCmdDeviceTelemetryQuery is the command for querying device telemetry.
+func NewCmdDeviceTelemetryQuery() *cobra.Command {
+	return &cobra.Command{
+		Use:   "query ID",
+		Short: "Query historical telemetry for a device",
+		Long: `Query historical telemetry for a device and show or export it.
+
+Values are retrieved a page at a time, so long ranges can be exported without
+any extra steps. Use --aggregation with --bucket to combine values, for example
+the average of every 5 minutes.
+
+Formats:
+  table     Interactive table, or tab separated with --plain
+  csv       A timestamp column and a column for each parameter, as read by import
+  ndjson    A JSON object per value
+  columnar  A JSON file storing the values of each parameter together`,
+		Example: `aware device telemetry query 5d1d574439d157849090ea6a --from 2026-01-01 --to 2026-01-02
+aware device telemetry query 5d1d574439d157849090ea6a --from 2026-01-01 --aggregation avg --bucket 1h --format csv -o hourly.csv
+aware device telemetry query 5d1d574439d157849090ea6a --parameters pilot-forward-resistance --format ndjson`,
+		Args: cobra.ExactArgs(1),
+		Run:  query,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	aggregations := make([]string, 0, len(aware.TelemetryAggregations))
+	for _, a := range aware.TelemetryAggregations {
+		aggregations = append(aggregations, string(a))
+	}
+
+	cmd.Flags().String("from", "", "Start of the range, as a date (2006-01-02) or RFC3339 timestamp (default is 24 hours before --to)")
+	cmd.Flags().String("to", "", "End of the range, as a date (2006-01-02) or RFC3339 timestamp (default is now)")
+	cmd.Flags().StringSlice("parameters", nil, "Only query these parameters, by name or display name")
+	cmd.Flags().String("aggregation", "", "Combine the values in each bucket, one of "+strings.Join(aggregations, ", "))
+	cmd.Flags().Duration("bucket", 0, "Size of each bucket when aggregating")
+	cmd.Flags().String("format", formatTable, "Output format, one of "+strings.Join(formats, ", "))
+	cmd.Flags().StringP("output", "o", "", "File to write to instead of stdout")
+	cmd.Flags().Int("page-size", 1000, "Maximum number of values retrieved in each request")
+	cmd.Flags().Bool("plain", false, "Display the table in plain mode")
+	cmd.Flags().Bool("no-headers", false, "Don't display headers in plain mode")
+}
+
+func query(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	qc := queryCmd{
+		client: client,
+		params: params,
+	}
+
+	utils.ExitIfError(qc.setDevice())
+
+	if len(params.parameters) > 0 {
+		utils.ExitIfError(qc.filterParameters())
+	}
+
+	qc.series = newSeries(qc.device.DeviceType.Parameters)
+
+	if params.format == formatTable {
+		utils.ExitIfError(qc.fetch(nil))
+		utils.ExitIfError(qc.renderTable())
+		return
+	}
+
+	if params.output == "" {
+		utils.ExitIfError(qc.export(os.Stdout))
+		return
+	}
+
+	utils.ExitIfError(qc.exportFile(params.output))
+	utils.Success("Exported %d values to %s", qc.count, params.output)
+}
+
+func (q *queryCmd) setDevice() error {
+	s := utils.ShowLoading(fmt.Sprintf("Fetching Device %s", q.params.ID))
+	defer s.Stop()
+
+	device, err := q.client.GetDeviceByID(q.params.ID)
+	if err != nil {
+		return err
+	}
+
+	q.device = device
+	return nil
+}
+
+func (q *queryCmd) filterParameters() error {
+	parameters := make([]aware.DeviceTypeParameter, 0, len(q.params.parameters))
+	for _, name := range q.params.parameters {
+		found := false
+		for _, parameter := range q.device.DeviceType.Parameters {
+			if parameter.Name == name || parameter.DisplayName == name {
+				parameters = append(parameters, parameter)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("device has no parameter named %s", name)
+		}
+	}
+
+	q.device.DeviceType.Parameters = parameters
+	return nil
+}
+
+// fetch retrieves every page of telemetry, adding the values to the series.
+// Each page is also written with write if it is given.
+func (q *queryCmd) fetch(write func([]*aware.TelemetryValue) error) error {
+	s := utils.ShowLoading("Fetching telemetry...")
+	defer s.Stop()
+
+	var names []string
+	for _, parameter := range q.device.DeviceType.Parameters {
+		names = append(names, parameter.Name)
+	}
+
+	opts := aware.GetTelemetryOptions{
+		Start:       q.params.from,
+		End:         q.params.to,
+		Aggregation: q.params.aggregation,
+		Bucket:      q.params.bucket,
+		Limit:       q.params.pageSize,
+	}
+	if len(q.params.parameters) > 0 {
+		opts.Parameters = names
+	}
+
+	pages := 0
+	return q.client.GetAllTelemetry(q.device.ID, opts, func(page *aware.TelemetryPage) error {
+		pages++
+		q.count += len(page.Values)
+
+		s.Lock()
+		s.Suffix = fmt.Sprintf(" Fetching telemetry... %d values from %d pages", q.count, pages)
+		s.Unlock()
+
+		if write != nil {
+			return write(page.Values)
+		}
+
+		q.series.add(page.Values)
+		return nil
+	})
+}
+
+func (q *queryCmd) export(w io.Writer) error {
+	switch q.params.format {
+	case formatNDJSON:
+		// Each page is written as it arrives so large ranges aren't held in memory
+		return q.fetch(func(values []*aware.TelemetryValue) error {
+			return writeNDJSON(w, values)
+		})
+	case formatCSV:
+		if err := q.fetch(nil); err != nil {
+			return err
+		}
+		return writeCSV(w, q.series)
+	case formatColumnar:
+		if err := q.fetch(nil); err != nil {
+			return err
+		}
+		return writeColumnar(w, q.device.ID, q.series)
+	}
+
+	return fmt.Errorf("unknown format %s", q.params.format)
+}
+
+// exportFile exports the telemetry to a new file, which is only complete once it
+// has been closed without error.
+func (q *queryCmd) exportFile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := q.export(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (q *queryCmd) renderTable() error {
+	rows := make(chan table.Row)
+	close(rows)
+
+	t := view.TelemetryTable{
+		Devices: []*aware.Device{q.device},
+		Display: view.TelemetryTableDisplayFormat{
+			Plain:     q.params.plain,
+			NoHeaders: q.params.noHeaders,
+		},
+		Rows: rows,
+		Status: func() string {
+			return fmt.Sprintf("%d values from %s to %s", q.count, q.params.from.Format(time.RFC3339), q.params.to.Format(time.RFC3339))
+		},
+	}
+
+	timestamps, values := q.series.sorted()
+	for i, ts := range timestamps {
+		t.InitialRows = append(t.InitialRows, t.FormatRow(q.device, ts, values[i]))
+	}
+
+	return t.Render()
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation(dateLayout, value, time.Local); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *queryParams {
+	toFlag, err := cmd.Flags().GetString("to")
+	utils.ExitIfError(err)
+
+	to := time.Now()
+	if toFlag != "" {
+		to, err = parseTime(toFlag)
+		utils.ExitIfError(err)
+	}
+
+	fromFlag, err := cmd.Flags().GetString("from")
+	utils.ExitIfError(err)
+
+	from := to.Add(-24 * time.Hour)
+	if fromFlag != "" {
+		from, err = parseTime(fromFlag)
+		utils.ExitIfError(err)
+	}
+
+	if !from.Before(to) {
+		utils.Failed("--from must be before --to")
+	}
+
+	parameters, err := cmd.Flags().GetStringSlice("parameters")
+	utils.ExitIfError(err)
+
+	aggregationFlag, err := cmd.Flags().GetString("aggregation")
+	utils.ExitIfError(err)
+
+	aggregation := aware.TelemetryAggregation(strings.ToLower(aggregationFlag))
+	if aggregation != aware.AggregationNone {
+		valid := false
+		for _, a := range aware.TelemetryAggregations {
+			valid = valid || a == aggregation
+		}
+		if !valid {
+			utils.Failed("Unknown aggregation %s", aggregationFlag)
+		}
+	}
+
+	bucket, err := cmd.Flags().GetDuration("bucket")
+	utils.ExitIfError(err)
+
+	if aggregation != aware.AggregationNone && bucket < time.Second {
+		utils.Failed("--bucket of at least 1s is required with --aggregation")
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	utils.ExitIfError(err)
+
+	format = strings.ToLower(format)
+	valid := false
+	for _, f := range formats {
+		valid = valid || f == format
+	}
+	if !valid {
+		utils.Failed("--format must be one of %s", strings.Join(formats, ", "))
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	utils.ExitIfError(err)
+
+	if output != "" && format == formatTable {
+		utils.Failed("--output can't be used with the table format")
+	}
+
+	pageSize, err := cmd.Flags().GetInt("page-size")
+	utils.ExitIfError(err)
+
+	plain, err := cmd.Flags().GetBool("plain")
+	utils.ExitIfError(err)
+
+	noHeaders, err := cmd.Flags().GetBool("no-headers")
+	utils.ExitIfError(err)
+
+	return &queryParams{
+		ID:          args[0],
+		from:        from,
+		to:          to,
+		parameters:  parameters,
+		aggregation: aggregation,
+		bucket:      bucket,
+		format:      format,
+		output:      output,
+		pageSize:    pageSize,
+		plain:       plain,
+		noHeaders:   noHeaders,
+	}
+}
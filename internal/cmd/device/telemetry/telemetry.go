@@ -2,7 +2,11 @@
 package telemetry
 
 import (
+	"ampaware.com/cli/internal/cmd/device/telemetry/backfill"
 	"ampaware.com/cli/internal/cmd/device/telemetry/generate"
+	"ampaware.com/cli/internal/cmd/device/telemetry/imports"
+	"ampaware.com/cli/internal/cmd/device/telemetry/query"
+	"ampaware.com/cli/internal/cmd/device/telemetry/watch"
 	"github.com/spf13/cobra"
 )
 
@@ -18,12 +22,24 @@ func NewCmdDeviceTelemetry() *cobra.Command {
 	}
 
 	gen := generate.NewCmdDeviceTelemetryGenerate()
+	bf := backfill.NewCmdDeviceTelemetryBackfill()
+	imp := imports.NewCmdDeviceTelemetryImport()
+	wa := watch.NewCmdDeviceTelemetryWatch()
+	qu := query.NewCmdDeviceTelemetryQuery()
 
 	cmd.AddCommand(
 		gen,
+		bf,
+		imp,
+		wa,
+		qu,
 	)
 
 	generate.SetFlags(gen)
+	backfill.SetFlags(bf)
+	imports.SetFlags(imp)
+	watch.SetFlags(wa)
+	query.SetFlags(qu)
 
 	return &cmd
 }
@@ -0,0 +1,208 @@
+// Package watch contains the command for watching the latest device telemetry.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type watchParams struct {
+	IDs       []string
+	interval  time.Duration
+	plain     bool
+	noHeaders bool
+}
+
+type watchCmd struct {
+	client  *aware.Client
+	params  *watchParams
+	devices []*aware.Device
+
+	mu         sync.Mutex
+	lastUpdate time.Time
+	lastError  error
+}
+
+// NewCmdDeviceTelemetryWatch is the command for watching device telemetry.
+func NewCmdDeviceTelemetryWatch() *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch ID...",
+		Short: "Watch the latest telemetry of one or more devices",
+		Long: `Watch the latest telemetry of one or more devices.
+
+The latest value of every parameter is polled from AWARE and shown with its
+unit, values that changed since the previous poll are highlighted. With --plain
+a line is written each time a parameter has a new value.`,
+		Example: `aware device telemetry watch 5d1d574439d157849090ea6a
+aware device telemetry watch 5d1d574439d157849090ea6a 5d1d574439d157849090ea6b --interval 10s
+aware device telemetry watch 5d1d574439d157849090ea6a --plain | grep resistance`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  watch,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("interval", 5*time.Second, "How often to poll for the latest values")
+	cmd.Flags().Bool("plain", false, "Display output in plain mode")
+	cmd.Flags().Bool("no-headers", false, "Don't display headers in plain mode")
+}
+
+func watch(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	wc := watchCmd{
+		client: client,
+		params: params,
+	}
+
+	utils.ExitIfError(wc.setDevices())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+	go func() {
+		select {
+		case <-signalChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	updates := make(chan []*aware.TelemetryValue)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		wc.poll(ctx, updates)
+	}()
+
+	t := view.LatestTelemetryTable{
+		Devices: wc.devices,
+		Display: view.TelemetryTableDisplayFormat{
+			Plain:     params.plain,
+			NoHeaders: params.noHeaders,
+		},
+		Updates: updates,
+		Status:  wc.status,
+		Quit:    ctx.Done(),
+	}
+
+	utils.ExitIfError(t.Render())
+
+	cancel()
+	<-stopped
+}
+
+func (w *watchCmd) setDevices() error {
+	s := utils.ShowLoading("Fetching Devices...")
+	defer s.Stop()
+
+	for _, id := range w.params.IDs {
+		device, err := w.client.GetDeviceByID(id)
+		if err != nil {
+			return err
+		}
+		w.devices = append(w.devices, device)
+	}
+
+	return nil
+}
+
+// poll sends the latest values of every device each interval until the context is done.
+// The updates channel is closed once polling has stopped.
+func (w *watchCmd) poll(ctx context.Context, updates chan<- []*aware.TelemetryValue) {
+	defer close(updates)
+
+	ticker := time.NewTicker(w.params.interval)
+	defer ticker.Stop()
+
+	for {
+		var values []*aware.TelemetryValue
+		var pollErr error
+		for _, device := range w.devices {
+			latest, err := w.client.GetLatestTelemetry(device.ID)
+			if err != nil {
+				pollErr = fmt.Errorf("%s: %w", device.DisplayName, err)
+				continue
+			}
+			values = append(values, latest...)
+		}
+
+		// The error is only shown until a poll succeeds for every device
+		w.mu.Lock()
+		w.lastUpdate = time.Now()
+		w.lastError = pollErr
+		w.mu.Unlock()
+
+		select {
+		case updates <- values:
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// status describes when the values were last polled for display.
+func (w *watchCmd) status() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	status := fmt.Sprintf("Polling every %s", w.params.interval)
+	if !w.lastUpdate.IsZero() {
+		status += fmt.Sprintf(", last updated %s", w.lastUpdate.Format(time.Kitchen))
+	}
+	if w.lastError != nil {
+		status += fmt.Sprintf(" (last error: %s)", w.lastError)
+	}
+
+	return status
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *watchParams {
+	interval, err := cmd.Flags().GetDuration("interval")
+	utils.ExitIfError(err)
+
+	if interval <= 0 {
+		utils.Failed("--interval must be greater than 0")
+	}
+
+	plain, err := cmd.Flags().GetBool("plain")
+	utils.ExitIfError(err)
+
+	noHeaders, err := cmd.Flags().GetBool("no-headers")
+	utils.ExitIfError(err)
+
+	return &watchParams{
+		IDs:       args,
+		interval:  interval,
+		plain:     plain,
+		noHeaders: noHeaders,
+	}
+}
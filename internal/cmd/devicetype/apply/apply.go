@@ -0,0 +1,245 @@
+// Package apply contains the command for creating or updating a device type from a YAML definition.
+package apply
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"ampaware.com/cli/internal/cmd/devicetype/definition"
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type applyParams struct {
+	file   string
+	dryRun bool
+	force  bool
+}
+
+type applyCmd struct {
+	client     *aware.Client
+	params     *applyParams
+	definition *definition.Definition
+	existing   *aware.DeviceType
+}
+
+// NewCmdApply is the command for applying a device type definition.
+func NewCmdApply() *cobra.Command {
+	return &cobra.Command{
+		Use:   "apply -f FILE",
+		Short: "Create or update a device type from YAML",
+		Long: `Create or update a device type from a YAML definition written by export.
+
+The device type with the same name and kind is updated, or a new one is created if there
+isn't one. The changes are shown and confirmed before they are applied, unless --force
+is set. Parameters keep their IDs when their names match so existing telemetry stays
+linked, removed parameters lose the link to their telemetry.`,
+		Example: `aware device-type apply -f ipb.yaml
+aware device-type apply -f ipb.yaml --dry-run
+aware device-type export 5cf717e2bec882982729dd8a | aware device-type apply -f - --force`,
+		Args: cobra.NoArgs,
+		Run:  apply,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("file", "f", "", "The definition to apply, - reads from stdin")
+	cmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	cmd.Flags().Bool("force", false, "Apply the changes without confirming")
+}
+
+func apply(cmd *cobra.Command, _ []string) {
+	params := parseFlags(cmd)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	ac := applyCmd{
+		client: client,
+		params: params,
+	}
+
+	utils.ExitIfError(ac.readDefinition())
+	utils.ExitIfError(ac.setExisting())
+
+	changes := definition.Diff(ac.existing, ac.definition)
+	if ac.existing != nil && len(changes) == 0 {
+		utils.Success("Device type %s is up to date\n%s", ac.definition.Name, ac.existing.ID)
+		return
+	}
+
+	if ac.existing == nil {
+		fmt.Printf("Creating device type %s (%s)\n", ac.definition.Name, ac.definition.Kind)
+	} else {
+		fmt.Printf("Updating device type %s (%s) %s\n", ac.definition.Name, ac.definition.Kind, ac.existing.ID)
+	}
+	removed := 0
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+		if change.Op == "-" && strings.HasPrefix(change.Item, "parameter ") {
+			removed++
+		}
+	}
+	fmt.Println()
+
+	if removed > 0 {
+		utils.Warn("%d removed parameter(s) will no longer be linked to their telemetry", removed)
+	}
+
+	if ac.params.dryRun {
+		return
+	}
+
+	if !ac.params.force {
+		var confirm bool
+
+		qs := &survey.Question{
+			Name:     "confirm",
+			Prompt:   &survey.Confirm{Message: fmt.Sprintf("Apply %d changes?", len(changes))},
+			Validate: survey.Required,
+		}
+
+		if err := survey.Ask([]*survey.Question{qs}, &confirm); err != nil {
+			utils.ExitIfError(err)
+		}
+
+		if !confirm {
+			return
+		}
+	}
+
+	ID, err := func() (string, error) {
+		s := utils.ShowLoading("Applying Device Type...")
+		defer s.Stop()
+
+		return ac.apply()
+	}()
+	utils.ExitIfError(err)
+
+	if ac.existing == nil {
+		utils.Success("Device type created\n%s", ID)
+	} else {
+		utils.Success("Device type updated\n%s", ID)
+	}
+}
+
+// apply creates or updates the device type, then sets up display groups of new parameters
+// once they have IDs.
+func (a *applyCmd) apply() (string, error) {
+	req, complete := a.definition.Request(a.existing)
+
+	var id string
+	if a.existing == nil {
+		req.Organisation = viper.GetString("organisation")
+
+		created, err := a.client.CreateDeviceType((*aware.CreateDeviceTypeRequest)(req))
+		if err != nil {
+			return "", err
+		}
+		id = created.ID
+	} else {
+		id = a.existing.ID
+		if err := a.client.UpdateDeviceTypeByID(id, req); err != nil {
+			return "", err
+		}
+	}
+
+	if complete {
+		return id, nil
+	}
+
+	current, err := a.client.GetDeviceTypeByID(id)
+	if err != nil {
+		return id, err
+	}
+
+	req, complete = a.definition.Request(current)
+	if !complete {
+		return id, fmt.Errorf("display groups refer to parameters that weren't created on %s", id)
+	}
+
+	return id, a.client.UpdateDeviceTypeByID(id, req)
+}
+
+func (a *applyCmd) readDefinition() error {
+	var r io.Reader = os.Stdin
+	if a.params.file != "-" {
+		f, err := os.Open(a.params.file)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	d, err := definition.Read(r)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", a.params.file, err)
+	}
+
+	a.definition = d
+	return nil
+}
+
+// setExisting finds the device type with the same name and kind as the definition.
+func (a *applyCmd) setExisting() error {
+	s := utils.ShowLoading("Fetching Device Types...")
+	defer s.Stop()
+
+	deviceTypes, err := a.client.GetAllDeviceTypes(viper.GetString("organisation"))
+	if err != nil {
+		return err
+	}
+
+	for _, t := range deviceTypes {
+		if t.Name != a.definition.Name || t.Kind != a.definition.Kind {
+			continue
+		}
+		if a.existing != nil {
+			return fmt.Errorf("more than one device type is named %s with kind %s", t.Name, t.Kind)
+		}
+		a.existing = t
+	}
+
+	if a.existing == nil {
+		return nil
+	}
+
+	// The list may not include every detail of the device type
+	a.existing, err = a.client.GetDeviceTypeByID(a.existing.ID)
+	return err
+}
+
+func parseFlags(cmd *cobra.Command) *applyParams {
+	file, err := cmd.Flags().GetString("file")
+	utils.ExitIfError(err)
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	utils.ExitIfError(err)
+
+	force, err := cmd.Flags().GetBool("force")
+	utils.ExitIfError(err)
+
+	if file == "" {
+		utils.Failed("A definition is required, set it with --file")
+	}
+	if file == "-" && !force && !dryRun {
+		utils.Failed("--force is required when reading the definition from stdin")
+	}
+
+	return &applyParams{
+		file:   file,
+		dryRun: dryRun,
+		force:  force,
+	}
+}
@@ -0,0 +1,187 @@
+// Package clone contains the command for copying a device type.
+package clone
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type cloneParams struct {
+	ID   string
+	name string
+	kind string
+}
+
+type cloneCmd struct {
+	client      *aware.Client
+	params      *cloneParams
+	deviceTypes []*aware.DeviceType
+}
+
+// NewCmdClone is the command for copying a device type.
+func NewCmdClone() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clone [ID]",
+		Short: "Copy a device type",
+		Long: `Create a new device type in the organisation with the parameters of an existing one.
+The copy is named "Copy of <name>" unless --name is given. If no ID is given the
+device type can be picked from a list.`,
+		Example: `aware device-type clone 5cf717e2bec882982729dd8a
+aware device-type clone 5cf717e2bec882982729dd8a --name "Relay v2" --kind relay-v2`,
+		Aliases: []string{"copy", "cp"},
+		Args:    cobra.MaximumNArgs(1),
+		Run:     clone,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("name", "", "Name of the copy.")
+	cmd.Flags().String("kind", "", "Kind of the copy, the same kind is used if not given.")
+}
+
+func clone(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	cc := cloneCmd{
+		client: client,
+		params: params,
+	}
+
+	if cc.params.ID == "" {
+		utils.ExitIfError(cc.setDeviceTypes())
+		utils.ExitIfError(cc.getDeviceTypeID())
+	}
+
+	ID, err := func() (string, error) {
+		s := utils.ShowLoading(fmt.Sprintf("Copying Device Type %s", cc.params.ID))
+		defer s.Stop()
+
+		source, err := client.GetDeviceTypeByID(cc.params.ID)
+		if err != nil {
+			return "", err
+		}
+
+		resp, err := client.CreateDeviceType(copyRequest(source, cc.params.name, cc.params.kind, viper.GetString("organisation")))
+		if err != nil {
+			return "", err
+		}
+		return resp.ID, nil
+	}()
+	utils.ExitIfError(err)
+	utils.Success("Device type created\n%s", ID)
+}
+
+// copyRequest creates a request for a copy of the device type in the organisation.
+// The IDs of parameters, alarms and commands are cleared so new ones are created.
+// Display groups refer to the parameters by ID so they are left for the copy to set up.
+func copyRequest(source *aware.DeviceType, name, kind, org string) *aware.CreateDeviceTypeRequest {
+	if name == "" {
+		name = "Copy of " + source.Name
+	}
+	if kind == "" {
+		kind = source.Kind
+	}
+
+	parameters := make([]aware.DeviceTypeParameter, 0, len(source.Parameters))
+	for _, p := range source.Parameters {
+		p.ID = ""
+		p.Alarms = append([]aware.DeviceTypeParameterAlarm(nil), p.Alarms...)
+		for i := range p.Alarms {
+			p.Alarms[i].ID = ""
+		}
+		parameters = append(parameters, p)
+	}
+
+	commands := make([]aware.DeviceTypeCommand, 0, len(source.Commands))
+	for _, c := range source.Commands {
+		c.ID = ""
+		commands = append(commands, c)
+	}
+
+	return &aware.CreateDeviceTypeRequest{
+		Name:              name,
+		Kind:              kind,
+		Description:       source.Description,
+		Organisation:      org,
+		Options:           source.Options,
+		AllowedAttributes: source.AllowedAttributes,
+		Parameters:        parameters,
+		Commands:          commands,
+	}
+}
+
+func (c *cloneCmd) setDeviceTypes() error {
+	s := utils.ShowLoading("Fetching Device Types...")
+	defer s.Stop()
+
+	deviceTypes, err := c.client.GetAllDeviceTypes(viper.GetString("organisation"))
+	if err != nil {
+		return err
+	}
+
+	c.deviceTypes = deviceTypes
+	return nil
+}
+
+func (c *cloneCmd) getDeviceTypeID() error {
+	var ans string
+
+	options := make([]string, 0, len(c.deviceTypes))
+	for _, t := range c.deviceTypes {
+		options = append(options, t.ID+" - "+t.Name)
+	}
+
+	qs := &survey.Question{
+		Name: "id",
+		Prompt: &survey.Select{
+			Message: "Device Type:",
+			Options: options,
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return err
+	}
+
+	for i, t := range c.deviceTypes {
+		if ans == options[i] {
+			c.params.ID = t.ID
+			break
+		}
+	}
+
+	return nil
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *cloneParams {
+	name, err := cmd.Flags().GetString("name")
+	utils.ExitIfError(err)
+
+	kind, err := cmd.Flags().GetString("kind")
+	utils.ExitIfError(err)
+
+	var id string
+	if len(args) >= 1 {
+		id = args[0]
+	}
+
+	return &cloneParams{
+		ID:   id,
+		name: name,
+		kind: kind,
+	}
+}
@@ -0,0 +1,48 @@
+package clone
+
+import (
+	"testing"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+func TestCopyRequest(t *testing.T) {
+	is := is.New(t)
+
+	source := &aware.DeviceType{
+		ID:           "TYPE-1",
+		Name:         "Relay",
+		Kind:         "relay",
+		Description:  "Protection relay",
+		Organisation: "ORG-1",
+		IsShared:     true,
+		Parameters: []aware.DeviceTypeParameter{
+			{ID: "PARAM-1", Name: "voltage", ValueType: aware.Float},
+			{ID: "PARAM-2", Name: "current", Alarms: []aware.DeviceTypeParameterAlarm{{ID: "ALARM-1", Name: "Overcurrent"}}},
+		},
+		DisplayGroups: []aware.DeviceTypeDisplayGroup{{ID: "GROUP-1", SelectedParameters: []string{"PARAM-1"}}},
+		Commands:      []aware.DeviceTypeCommand{{ID: "COMMAND-1", Name: "trip"}},
+	}
+
+	req := copyRequest(source, "", "", "ORG-2")
+	is.Equal(req.Name, "Copy of Relay")
+	is.Equal(req.Kind, "relay")
+	is.Equal(req.Organisation, "ORG-2")
+	is.Equal(req.IsShared, false)
+	is.Equal(req.Parameters, []aware.DeviceTypeParameter{
+		{Name: "voltage", ValueType: aware.Float},
+		{Name: "current", Alarms: []aware.DeviceTypeParameterAlarm{{Name: "Overcurrent"}}},
+	})
+	is.Equal(req.Commands, []aware.DeviceTypeCommand{{Name: "trip"}})
+	is.Equal(len(req.DisplayGroups), 0)
+
+	// The source isn't changed
+	is.Equal(source.Parameters[0].ID, "PARAM-1")
+	is.Equal(source.Parameters[1].Alarms[0].ID, "ALARM-1")
+	is.Equal(source.Commands[0].ID, "COMMAND-1")
+
+	req = copyRequest(source, "Relay v2", "relay-v2", "ORG-1")
+	is.Equal(req.Name, "Relay v2")
+	is.Equal(req.Kind, "relay-v2")
+}
@@ -0,0 +1,162 @@
+// Package create contains the command for creating a new device type.
+package create
+
+import (
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type createParams struct {
+	name         string
+	kind         string
+	description  string
+	organisation string
+	shared       bool
+	noInput      bool
+}
+
+type createCmd struct {
+	client *aware.Client
+	params *createParams
+}
+
+// NewCmdCreate is the create device type command.
+func NewCmdCreate() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create",
+		Short: "Create a new device type",
+		Long: `Create a new device type without any parameters, use clone to start from an existing type.
+Any details not given as flags are asked for, unless --no-input is set.`,
+		Example: `aware device-type create
+aware device-type create --name "Protection Relay" --kind protection-relay --no-input`,
+		Run: create,
+	}
+}
+
+// SetFlags sets the flags support by the create command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("name", "", "Set the Name.")
+	cmd.Flags().String("kind", "", "Set the Kind.")
+	cmd.Flags().String("description", "", "Set the Description.")
+	cmd.Flags().Bool("shared", false, "Share the device type with other organisations.")
+	cmd.Flags().Bool("no-input", false, "Disable prompt for non-required fields")
+}
+
+func create(cmd *cobra.Command, _ []string) {
+	params := parseFlags(cmd)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	cc := createCmd{
+		client: client,
+		params: params,
+	}
+
+	utils.ExitIfError(cc.askQuestions())
+
+	if cc.params.name == "" || cc.params.kind == "" {
+		utils.Failed("A device type requires a name and kind")
+	}
+
+	ID, err := func() (string, error) {
+		s := utils.ShowLoading("Creating Device Type...")
+		defer s.Stop()
+
+		resp, err := client.CreateDeviceType(&aware.CreateDeviceTypeRequest{
+			Name:         params.name,
+			Kind:         params.kind,
+			Description:  params.description,
+			Organisation: params.organisation,
+			IsShared:     params.shared,
+			Parameters:   []aware.DeviceTypeParameter{},
+		})
+		if err != nil {
+			return "", err
+		}
+		return resp.ID, nil
+	}()
+	utils.ExitIfError(err)
+	utils.Success("Device type created\n%s", ID)
+}
+
+func (c *createCmd) askQuestions() error {
+	var qs []*survey.Question
+
+	if c.params.name == "" {
+		qs = append(qs, &survey.Question{
+			Name:     "name",
+			Prompt:   &survey.Input{Message: "Name:"},
+			Validate: survey.Required,
+		})
+	}
+
+	if c.params.kind == "" {
+		qs = append(qs, &survey.Question{
+			Name:     "kind",
+			Prompt:   &survey.Input{Message: "Kind:", Help: "A short identifier such as protection-relay"},
+			Validate: survey.Required,
+		})
+	}
+
+	if c.params.description == "" && !c.params.noInput {
+		qs = append(qs, &survey.Question{
+			Name:   "description",
+			Prompt: &survey.Input{Message: "Description:"},
+		})
+	}
+
+	ans := struct {
+		Name        string
+		Kind        string
+		Description string
+	}{}
+	if err := survey.Ask(qs, &ans); err != nil {
+		return err
+	}
+
+	if c.params.name == "" {
+		c.params.name = ans.Name
+	}
+	if c.params.kind == "" {
+		c.params.kind = ans.Kind
+	}
+	if c.params.description == "" {
+		c.params.description = ans.Description
+	}
+
+	return nil
+}
+
+func parseFlags(cmd *cobra.Command) *createParams {
+	name, err := cmd.Flags().GetString("name")
+	utils.ExitIfError(err)
+
+	kind, err := cmd.Flags().GetString("kind")
+	utils.ExitIfError(err)
+
+	description, err := cmd.Flags().GetString("description")
+	utils.ExitIfError(err)
+
+	shared, err := cmd.Flags().GetBool("shared")
+	utils.ExitIfError(err)
+
+	noInput, err := cmd.Flags().GetBool("no-input")
+	utils.ExitIfError(err)
+
+	return &createParams{
+		name:         name,
+		kind:         kind,
+		description:  description,
+		organisation: viper.GetString("organisation"),
+		shared:       shared,
+		noInput:      noInput,
+	}
+}
@@ -0,0 +1,245 @@
+package definition
+
+import "ampaware.com/cli/pkg/aware"
+
+// FromDeviceType creates the definition of the device type.
+func FromDeviceType(t *aware.DeviceType) *Definition {
+	d := &Definition{
+		Version:     Version,
+		Name:        t.Name,
+		Kind:        t.Kind,
+		Description: t.Description,
+		Shared:      t.IsShared,
+		Options:     t.Options,
+		Parameters:  []Parameter{},
+	}
+
+	for _, a := range t.AllowedAttributes {
+		d.Attributes = append(d.Attributes, Attribute{
+			Name:        a.Name,
+			DisplayName: a.DisplayName,
+			ValueType:   a.ValueType,
+			Required:    a.IsRequired,
+			Default:     a.Default,
+		})
+	}
+
+	names := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		names[p.ID] = p.Name
+
+		parameter := Parameter{
+			Name:         p.Name,
+			DisplayName:  p.DisplayName,
+			ValueType:    string(p.ValueType),
+			Inactive:     !p.IsActive,
+			Primary:      p.IsPrimary,
+			Aggregatable: p.IsAggregatable,
+			Range:        fromRange(p.Range),
+			Display: Display{
+				Unit:      p.Display.Unit,
+				Scale:     p.Display.Scale,
+				Range:     fromRange(p.Display.Range),
+				Component: p.Display.Component,
+			},
+		}
+		for _, v := range p.Display.Values {
+			parameter.Display.Values = append(parameter.Display.Values, DisplayValue{Value: v.Value, Label: v.Label, Color: v.Color})
+		}
+		for _, a := range p.Alarms {
+			parameter.Alarms = append(parameter.Alarms, Alarm{
+				Name:      a.Name,
+				Severity:  a.Severity,
+				Condition: a.Condition,
+				Threshold: a.Threshold,
+				Message:   a.Message,
+				Inactive:  !a.IsActive,
+			})
+		}
+		d.Parameters = append(d.Parameters, parameter)
+	}
+
+	for _, g := range t.DisplayGroups {
+		group := DisplayGroup{
+			Name:        g.Name,
+			DisplayName: g.DisplayName,
+			Type:        g.Type,
+			Order:       g.Order,
+			Parameters:  []string{},
+		}
+		// Parameters that aren't part of the type can't be referred to by name so are left out
+		for _, id := range g.SelectedParameters {
+			if name, ok := names[id]; ok {
+				group.Parameters = append(group.Parameters, name)
+			}
+		}
+		d.DisplayGroups = append(d.DisplayGroups, group)
+	}
+
+	for _, c := range t.Commands {
+		command := Command{
+			Name:        c.Name,
+			DisplayName: c.DisplayName,
+			Description: c.Description,
+		}
+		for _, a := range c.Arguments {
+			command.Arguments = append(command.Arguments, Argument{
+				Name:        a.Name,
+				DisplayName: a.DisplayName,
+				ValueType:   a.ValueType,
+				Required:    a.IsRequired,
+				Default:     a.Default,
+			})
+		}
+		d.Commands = append(d.Commands, command)
+	}
+
+	return d
+}
+
+// fromRange returns nil for an unset range so it is left out of the definition.
+func fromRange(r aware.DeviceTypeParameterRange) *Range {
+	if r.Min == 0 && r.Max == 0 {
+		return nil
+	}
+	return &Range{Min: r.Min, Max: r.Max}
+}
+
+func toRange(r *Range) aware.DeviceTypeParameterRange {
+	if r == nil {
+		return aware.DeviceTypeParameterRange{}
+	}
+	return aware.DeviceTypeParameterRange{Min: r.Min, Max: r.Max}
+}
+
+// Request creates the request to update the existing device type to the definition, or to create
+// it when existing is nil. The IDs of existing parameters, alarms, commands and display groups
+// are kept when their names match, so telemetry stays linked to the parameters.
+//
+// Display groups can only refer to parameters with IDs, so complete is false when a group refers
+// to a new parameter. The request should be made again once the parameter has been created.
+func (d *Definition) Request(existing *aware.DeviceType) (req *aware.UpdateDeviceTypeRequest, complete bool) {
+	if existing == nil {
+		existing = &aware.DeviceType{}
+	}
+
+	req = &aware.UpdateDeviceTypeRequest{
+		Name:         d.Name,
+		Kind:         d.Kind,
+		Description:  d.Description,
+		Organisation: existing.Organisation,
+		IsShared:     d.Shared,
+		Options:      d.Options,
+		Parameters:   make([]aware.DeviceTypeParameter, 0, len(d.Parameters)),
+	}
+
+	for _, a := range d.Attributes {
+		req.AllowedAttributes = append(req.AllowedAttributes, aware.DeviceTypeAttribute{
+			Name:        a.Name,
+			DisplayName: a.DisplayName,
+			ValueType:   a.ValueType,
+			IsRequired:  a.Required,
+			Default:     a.Default,
+		})
+	}
+
+	parameters := make(map[string]aware.DeviceTypeParameter, len(existing.Parameters))
+	for _, p := range existing.Parameters {
+		parameters[p.Name] = p
+	}
+
+	ids := make(map[string]string, len(d.Parameters))
+	for _, p := range d.Parameters {
+		current := parameters[p.Name]
+		ids[p.Name] = current.ID
+
+		alarms := make(map[string]string, len(current.Alarms))
+		for _, a := range current.Alarms {
+			alarms[a.Name] = a.ID
+		}
+
+		parameter := aware.DeviceTypeParameter{
+			ID:             current.ID,
+			Name:           p.Name,
+			DisplayName:    p.DisplayName,
+			ValueType:      aware.DeviceTypeParameterValueType(p.ValueType),
+			IsActive:       !p.Inactive,
+			IsPrimary:      p.Primary,
+			IsAggregatable: p.Aggregatable,
+			Range:          toRange(p.Range),
+			Display: aware.DeviceTypeParameterDisplay{
+				Unit:      p.Display.Unit,
+				Scale:     p.Display.Scale,
+				Range:     toRange(p.Display.Range),
+				Component: p.Display.Component,
+				Values:    []aware.DeviceTypeParameterDisplayValue{},
+			},
+		}
+		for _, v := range p.Display.Values {
+			parameter.Display.Values = append(parameter.Display.Values, aware.DeviceTypeParameterDisplayValue{Value: v.Value, Label: v.Label, Color: v.Color})
+		}
+		for _, a := range p.Alarms {
+			parameter.Alarms = append(parameter.Alarms, aware.DeviceTypeParameterAlarm{
+				ID:        alarms[a.Name],
+				Name:      a.Name,
+				Severity:  a.Severity,
+				Condition: a.Condition,
+				Threshold: a.Threshold,
+				Message:   a.Message,
+				IsActive:  !a.Inactive,
+			})
+		}
+		req.Parameters = append(req.Parameters, parameter)
+	}
+
+	groups := make(map[string]string, len(existing.DisplayGroups))
+	for _, g := range existing.DisplayGroups {
+		groups[g.Name] = g.ID
+	}
+
+	complete = true
+	for _, g := range d.DisplayGroups {
+		group := aware.DeviceTypeDisplayGroup{
+			ID:                 groups[g.Name],
+			Name:               g.Name,
+			Type:               g.Type,
+			DisplayName:        g.DisplayName,
+			Order:              g.Order,
+			SelectedParameters: make([]string, 0, len(g.Parameters)),
+		}
+		for _, name := range g.Parameters {
+			if ids[name] == "" {
+				complete = false
+				continue
+			}
+			group.SelectedParameters = append(group.SelectedParameters, ids[name])
+		}
+		req.DisplayGroups = append(req.DisplayGroups, group)
+	}
+
+	commands := make(map[string]string, len(existing.Commands))
+	for _, c := range existing.Commands {
+		commands[c.Name] = c.ID
+	}
+
+	for _, c := range d.Commands {
+		command := aware.DeviceTypeCommand{
+			ID:          commands[c.Name],
+			Name:        c.Name,
+			DisplayName: c.DisplayName,
+			Description: c.Description,
+		}
+		for _, a := range c.Arguments {
+			command.Arguments = append(command.Arguments, aware.DeviceTypeCommandArgument{
+				Name:        a.Name,
+				DisplayName: a.DisplayName,
+				ValueType:   a.ValueType,
+				IsRequired:  a.Required,
+				Default:     a.Default,
+			})
+		}
+		req.Commands = append(req.Commands, command)
+	}
+
+	return req, complete
+}
@@ -0,0 +1,170 @@
+// Package definition contains the YAML definition of a device type, used to keep
+// device types the same across environments.
+package definition
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the version of the definition format, definitions of other versions can't be applied.
+const Version = 1
+
+// Definition is a device type without anything specific to an environment, such as IDs.
+// Parameters, alarms, commands and display groups are matched by name when applied.
+type Definition struct {
+	Version       int            `yaml:"version"`
+	Name          string         `yaml:"name"`
+	Kind          string         `yaml:"kind"`
+	Description   string         `yaml:"description,omitempty"`
+	Shared        bool           `yaml:"shared,omitempty"`
+	Options       interface{}    `yaml:"options,omitempty"`
+	Attributes    []Attribute    `yaml:"attributes,omitempty"`
+	Parameters    []Parameter    `yaml:"parameters"`
+	DisplayGroups []DisplayGroup `yaml:"displayGroups,omitempty"`
+	Commands      []Command      `yaml:"commands,omitempty"`
+}
+
+// Attribute is an attribute devices of the type can have.
+type Attribute struct {
+	Name        string      `yaml:"name"`
+	DisplayName string      `yaml:"displayName,omitempty"`
+	ValueType   string      `yaml:"valueType,omitempty"`
+	Required    bool        `yaml:"required,omitempty"`
+	Default     interface{} `yaml:"default,omitempty"`
+}
+
+// Parameter is a parameter of the type.
+type Parameter struct {
+	Name         string  `yaml:"name"`
+	DisplayName  string  `yaml:"displayName"`
+	ValueType    string  `yaml:"valueType"`
+	Inactive     bool    `yaml:"inactive,omitempty"`
+	Primary      bool    `yaml:"primary,omitempty"`
+	Aggregatable bool    `yaml:"aggregatable,omitempty"`
+	Range        *Range  `yaml:"range,omitempty"`
+	Display      Display `yaml:"display,omitempty"`
+	Alarms       []Alarm `yaml:"alarms,omitempty"`
+}
+
+// Range is the range of a parameter's values.
+type Range struct {
+	Min float64 `yaml:"min"`
+	Max float64 `yaml:"max"`
+}
+
+// Display is how a parameter's values are shown.
+type Display struct {
+	Unit      string         `yaml:"unit,omitempty"`
+	Scale     float64        `yaml:"scale,omitempty"`
+	Range     *Range         `yaml:"range,omitempty"`
+	Component string         `yaml:"component,omitempty"`
+	Values    []DisplayValue `yaml:"values,omitempty"`
+}
+
+// DisplayValue is a label shown in place of a parameter value.
+type DisplayValue struct {
+	Value interface{} `yaml:"value"`
+	Label string      `yaml:"label"`
+	Color string      `yaml:"color,omitempty"`
+}
+
+// Alarm is an alarm raised by a parameter's value.
+type Alarm struct {
+	Name      string  `yaml:"name"`
+	Severity  string  `yaml:"severity"`
+	Condition string  `yaml:"condition"`
+	Threshold float64 `yaml:"threshold"`
+	Message   string  `yaml:"message,omitempty"`
+	Inactive  bool    `yaml:"inactive,omitempty"`
+}
+
+// DisplayGroup is a group of parameters shown together.
+type DisplayGroup struct {
+	Name        string   `yaml:"name"`
+	DisplayName string   `yaml:"displayName"`
+	Type        string   `yaml:"type"`
+	Order       int      `yaml:"order"`
+	Parameters  []string `yaml:"parameters"` // Parameter names
+}
+
+// Command is a command that can be sent to devices of the type.
+type Command struct {
+	Name        string     `yaml:"name"`
+	DisplayName string     `yaml:"displayName"`
+	Description string     `yaml:"description,omitempty"`
+	Arguments   []Argument `yaml:"arguments,omitempty"`
+}
+
+// Argument is an argument of a command.
+type Argument struct {
+	Name        string      `yaml:"name"`
+	DisplayName string      `yaml:"displayName,omitempty"`
+	ValueType   string      `yaml:"valueType"`
+	Required    bool        `yaml:"required,omitempty"`
+	Default     interface{} `yaml:"default,omitempty"`
+}
+
+// Read reads and validates a definition.
+func Read(r io.Reader) (*Definition, error) {
+	var d Definition
+
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&d); err != nil {
+		return nil, err
+	}
+
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// Write writes the definition as YAML.
+func Write(w io.Writer, d *Definition) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(d); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Validate checks the definition can be applied.
+func (d *Definition) Validate() error {
+	if d.Version != Version {
+		return fmt.Errorf("unsupported definition version %d, expected %d", d.Version, Version)
+	}
+	if d.Name == "" || d.Kind == "" {
+		return fmt.Errorf("a device type requires a name and kind")
+	}
+
+	parameters := make(map[string]struct{}, len(d.Parameters))
+	for _, p := range d.Parameters {
+		if p.Name == "" {
+			return fmt.Errorf("every parameter requires a name")
+		}
+		if _, ok := parameters[p.Name]; ok {
+			return fmt.Errorf("parameter %s is defined more than once", p.Name)
+		}
+		parameters[p.Name] = struct{}{}
+
+		if p.ValueType == "" {
+			return fmt.Errorf("parameter %s requires a value type", p.Name)
+		}
+	}
+
+	for _, g := range d.DisplayGroups {
+		for _, name := range g.Parameters {
+			if _, ok := parameters[name]; !ok {
+				return fmt.Errorf("display group %s has unknown parameter %s", g.Name, name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package definition
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+func deviceType(t *testing.T) *aware.DeviceType {
+	data, err := os.ReadFile("../../../../pkg/aware/test_data/device_type.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out aware.DeviceType
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	return &out
+}
+
+func TestExportApply(t *testing.T) {
+	is := is.New(t)
+
+	existing := deviceType(t)
+
+	var b bytes.Buffer
+	is.NoErr(Write(&b, FromDeviceType(existing)))
+	is.True(!strings.Contains(b.String(), existing.Parameters[0].ID)) // IDs are left out
+
+	d, err := Read(&b)
+	is.NoErr(err)
+	is.Equal(len(Diff(existing, d)), 0)
+
+	// Applying the export unchanged sends the device type as it is
+	req, complete := d.Request(existing)
+	is.True(complete)
+	is.Equal(req.Parameters, existing.Parameters)
+	is.Equal(req.DisplayGroups, existing.DisplayGroups)
+	is.Equal(req.Commands, existing.Commands)
+	is.Equal(req.AllowedAttributes, existing.AllowedAttributes)
+	is.Equal(req.Organisation, existing.Organisation)
+}
+
+func TestDiff(t *testing.T) {
+	is := is.New(t)
+
+	existing := deviceType(t)
+	d := FromDeviceType(existing)
+
+	d.Description = "Changed"
+	d.Parameters[0].DisplayName = "Speed"
+	d.Parameters[0].Alarms[0].Threshold = 2900
+	d.Parameters = append(d.Parameters[:1], Parameter{Name: "temperature", DisplayName: "Temperature", ValueType: "float"})
+	d.DisplayGroups[0].Parameters = []string{"motor-speed", "temperature"}
+
+	changes := make([]string, 0)
+	for _, c := range Diff(existing, d) {
+		changes = append(changes, c.String())
+	}
+	is.Equal(changes, []string{
+		"~ description",
+		"~ parameter motor-speed (displayName, alarms)",
+		"+ parameter temperature",
+		"- parameter run-state",
+		"~ display group Overview (parameters)",
+	})
+
+	req, complete := d.Request(existing)
+	is.True(!complete) // The group refers to the new parameter
+	is.Equal(req.Parameters[0].ID, existing.Parameters[0].ID)
+	is.Equal(req.Parameters[0].Alarms[0].ID, existing.Parameters[0].Alarms[0].ID)
+	is.Equal(req.Parameters[1].ID, "")
+	is.Equal(req.DisplayGroups[0].SelectedParameters, []string{existing.Parameters[0].ID})
+
+	// Once the parameter has been created the group can refer to it
+	existing.Parameters = append(existing.Parameters, aware.DeviceTypeParameter{ID: "NEW", Name: "temperature"})
+	req, complete = d.Request(existing)
+	is.True(complete)
+	is.Equal(req.DisplayGroups[0].SelectedParameters, []string{existing.Parameters[0].ID, "NEW"})
+}
+
+func TestDiffNew(t *testing.T) {
+	is := is.New(t)
+
+	d := FromDeviceType(deviceType(t))
+
+	changes := Diff(nil, d)
+	is.Equal(len(changes), 7) // Every attribute, parameter, group and command is added
+	for _, c := range changes {
+		is.Equal(c.Op, "+")
+	}
+
+	req, complete := d.Request(nil)
+	is.True(!complete)
+	is.Equal(req.Parameters[0].ID, "")
+	is.Equal(req.Commands[0].ID, "")
+}
+
+func TestRead(t *testing.T) {
+	is := is.New(t)
+
+	for _, tc := range []struct {
+		yaml string
+		err  string
+	}{
+		{"version: 2\nname: A\nkind: a\nparameters: []\n", "unsupported definition version 2, expected 1"},
+		{"version: 1\nname: A\nparameters: []\n", "a device type requires a name and kind"},
+		{"version: 1\nname: A\nkind: a\nparameters:\n  - name: v\n    valueType: float\n  - name: v\n    valueType: bool\n", "parameter v is defined more than once"},
+		{"version: 1\nname: A\nkind: a\nparameters:\n  - name: v\n", "parameter v requires a value type"},
+		{"version: 1\nname: A\nkind: a\nparameters: []\ndisplayGroups:\n  - name: G\n    parameters: [missing]\n", "display group G has unknown parameter missing"},
+		{"version: 1\nname: A\nkind: a\nunknown: true\n", "yaml: unmarshal errors:\n  line 4: field unknown not found in type definition.Definition"},
+	} {
+		_, err := Read(strings.NewReader(tc.yaml))
+		is.True(err != nil)
+		is.Equal(err.Error(), tc.err)
+	}
+}
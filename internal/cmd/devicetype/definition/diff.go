@@ -0,0 +1,118 @@
+package definition
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"ampaware.com/cli/pkg/aware"
+)
+
+// Change is a difference between a device type and its definition.
+type Change struct {
+	Op     string   // + for added, - for removed and ~ for changed
+	Item   string   // Such as "parameter voltage" or "description"
+	Fields []string // Fields of the item that changed
+}
+
+func (c Change) String() string {
+	s := c.Op + " " + c.Item
+	if len(c.Fields) > 0 {
+		s += " (" + strings.Join(c.Fields, ", ") + ")"
+	}
+	return s
+}
+
+// Diff returns the changes applying the definition would make to the device type,
+// when existing is nil everything in the definition is added.
+func Diff(existing *aware.DeviceType, d *Definition) []Change {
+	current := &Definition{}
+	if existing != nil {
+		current = FromDeviceType(existing)
+	}
+
+	var changes []Change
+	if existing != nil {
+		for _, field := range []struct {
+			name     string
+			old, new interface{}
+		}{
+			{"description", current.Description, d.Description},
+			{"shared", current.Shared, d.Shared},
+			{"options", current.Options, d.Options},
+		} {
+			if !jsonEqual(field.old, field.new) {
+				changes = append(changes, Change{Op: "~", Item: field.name})
+			}
+		}
+	}
+
+	changes = append(changes, diffNamed("attribute", current.Attributes, d.Attributes)...)
+	changes = append(changes, diffNamed("parameter", current.Parameters, d.Parameters)...)
+	changes = append(changes, diffNamed("display group", current.DisplayGroups, d.DisplayGroups)...)
+	changes = append(changes, diffNamed("command", current.Commands, d.Commands)...)
+
+	return changes
+}
+
+// diffNamed compares two slices of structs with a Name field, items are matched by name.
+func diffNamed(kind string, old, new interface{}) []Change {
+	oldItems := reflect.ValueOf(old)
+	newItems := reflect.ValueOf(new)
+
+	byName := make(map[string]reflect.Value, oldItems.Len())
+	for i := 0; i < oldItems.Len(); i++ {
+		item := oldItems.Index(i)
+		byName[item.FieldByName("Name").String()] = item
+	}
+
+	var changes []Change
+	seen := make(map[string]struct{}, newItems.Len())
+	for i := 0; i < newItems.Len(); i++ {
+		item := newItems.Index(i)
+		name := item.FieldByName("Name").String()
+		seen[name] = struct{}{}
+
+		current, ok := byName[name]
+		if !ok {
+			changes = append(changes, Change{Op: "+", Item: kind + " " + name})
+			continue
+		}
+		if fields := changedFields(current, item); len(fields) > 0 {
+			changes = append(changes, Change{Op: "~", Item: kind + " " + name, Fields: fields})
+		}
+	}
+
+	for i := 0; i < oldItems.Len(); i++ {
+		name := oldItems.Index(i).FieldByName("Name").String()
+		if _, ok := seen[name]; !ok {
+			changes = append(changes, Change{Op: "-", Item: kind + " " + name})
+		}
+	}
+
+	return changes
+}
+
+// changedFields returns the YAML names of the fields that differ between two structs of the same type.
+func changedFields(old, new reflect.Value) []string {
+	var fields []string
+	for i := 0; i < old.NumField(); i++ {
+		if !jsonEqual(old.Field(i).Interface(), new.Field(i).Interface()) {
+			name, _, _ := strings.Cut(old.Type().Field(i).Tag.Get("yaml"), ",")
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// jsonEqual compares values as they would be sent to AWARE, so numbers read from
+// YAML as integers are equal to the same numbers read from JSON.
+func jsonEqual(a, b interface{}) bool {
+	aj, aErr := json.Marshal(a)
+	bj, bErr := json.Marshal(b)
+	if aErr != nil || bErr != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return bytes.Equal(aj, bj)
+}
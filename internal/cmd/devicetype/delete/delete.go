@@ -0,0 +1,151 @@
+// Package delete contains the command for deleting a device type.
+package delete
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type deleteParams struct {
+	ID    string
+	force bool
+}
+
+type deleteCommand struct {
+	client      *aware.Client
+	params      *deleteParams
+	deviceTypes []*aware.DeviceType
+}
+
+// NewCmdDelete is the delete device type command.
+func NewCmdDelete() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [ID]",
+		Short: "Delete a device type",
+		Long: `Delete a device type. If no ID is given the device type can be picked from a list.
+The deletion is confirmed first unless --force is set.`,
+		Example: `aware device-type delete 5cf717e2bec882982729dd8a
+aware device-type delete 5cf717e2bec882982729dd8a --force`,
+		Aliases: []string{"remove", "rm", "del"},
+		Args:    cobra.MaximumNArgs(1),
+		Run:     del,
+	}
+}
+
+func del(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	del := deleteCommand{
+		client: client,
+		params: params,
+	}
+
+	if del.params.ID == "" {
+		utils.ExitIfError(del.setDeviceTypes())
+		utils.ExitIfError(del.getDeviceTypeID())
+	}
+
+	if !del.params.force {
+		var confirm bool
+
+		qs := &survey.Question{
+			Name:     "id",
+			Prompt:   &survey.Confirm{Message: fmt.Sprintf("Are you sure you want to delete %s?", del.params.ID)},
+			Validate: survey.Required,
+		}
+
+		if err := survey.Ask([]*survey.Question{qs}, &confirm); err != nil {
+			utils.ExitIfError(err)
+		}
+
+		if !confirm {
+			return
+		}
+	}
+
+	err := func() error {
+		s := utils.ShowLoading(fmt.Sprintf("Removing Device Type %s", del.params.ID))
+		defer s.Stop()
+
+		return del.client.DeleteDeviceType(del.params.ID)
+	}()
+	utils.ExitIfError(err)
+
+	utils.Success("Device type removed successfully\n%s", del.params.ID)
+}
+
+// SetFlags set the flags supported by the the delete command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("force", "f", false, "Force the deletion of the device type.")
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *deleteParams {
+	force, err := cmd.Flags().GetBool("force")
+	utils.ExitIfError(err)
+
+	var id string
+	if len(args) >= 1 {
+		id = args[0]
+	}
+
+	return &deleteParams{
+		ID:    id,
+		force: force,
+	}
+}
+
+func (d *deleteCommand) setDeviceTypes() error {
+	s := utils.ShowLoading("Fetching Device Types...")
+	defer s.Stop()
+
+	deviceTypes, err := d.client.GetAllDeviceTypes(viper.GetString("organisation"))
+	if err != nil {
+		return err
+	}
+
+	d.deviceTypes = deviceTypes
+	return nil
+}
+
+func (d *deleteCommand) getDeviceTypeID() error {
+	var ans string
+
+	options := make([]string, 0, len(d.deviceTypes))
+	for _, t := range d.deviceTypes {
+		options = append(options, t.ID+" - "+t.Name)
+	}
+
+	qs := &survey.Question{
+		Name: "id",
+		Prompt: &survey.Select{
+			Message: "Device Type:",
+			Options: options,
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return err
+	}
+
+	for i, t := range d.deviceTypes {
+		if ans == options[i] {
+			d.params.ID = t.ID
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Package devicetype contains the root command for all device type commands.
+package devicetype
+
+import (
+	"ampaware.com/cli/internal/cmd/devicetype/apply"
+	"ampaware.com/cli/internal/cmd/devicetype/clone"
+	"ampaware.com/cli/internal/cmd/devicetype/create"
+	"ampaware.com/cli/internal/cmd/devicetype/delete"
+	"ampaware.com/cli/internal/cmd/devicetype/edit"
+	"ampaware.com/cli/internal/cmd/devicetype/export"
+	"ampaware.com/cli/internal/cmd/devicetype/list"
+	"ampaware.com/cli/internal/cmd/devicetype/show"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdDeviceType is the root command for device-type.
+func NewCmdDeviceType() *cobra.Command {
+	cmd := cobra.Command{
+		Use:         "device-type",
+		Short:       "Manage Device Types in an Organisation",
+		Long:        "Manage the Device Types in an Organisation, which define the parameters a device publishes.",
+		Aliases:     []string{"device-types", "devicetype", "devicetypes"},
+		Annotations: map[string]string{},
+		RunE:        deviceType,
+	}
+
+	lc := list.NewCmdList()
+	sh := show.NewCmdView()
+	cr := create.NewCmdCreate()
+	ed := edit.NewCmdEdit()
+	de := delete.NewCmdDelete()
+	cl := clone.NewCmdClone()
+	ex := export.NewCmdExport()
+	ap := apply.NewCmdApply()
+
+	cmd.AddCommand(
+		lc,
+		sh,
+		cr,
+		ed,
+		de,
+		cl,
+		ex,
+		ap,
+	)
+
+	list.SetFlags(lc)
+	create.SetFlags(cr)
+	edit.SetFlags(ed)
+	delete.SetFlags(de)
+	clone.SetFlags(cl)
+	export.SetFlags(ex)
+	apply.SetFlags(ap)
+
+	return &cmd
+}
+
+func deviceType(cmd *cobra.Command, _ []string) error {
+	return cmd.Help()
+}
@@ -0,0 +1,229 @@
+// Package edit contains the command for editing an existing device type.
+package edit
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type editParams struct {
+	ID          string
+	name        string
+	kind        string
+	description string
+	noInput     bool
+}
+
+type editCmd struct {
+	client      *aware.Client
+	params      *editParams
+	deviceType  *aware.DeviceType
+	deviceTypes []*aware.DeviceType
+}
+
+// NewCmdEdit is the edit device type command.
+func NewCmdEdit() *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit [ID]",
+		Short: "Edit a device type",
+		Long: `Edit the name, kind or description of a device type, its parameters are kept.
+Any details not given as flags are asked for with the current value as the default,
+unless --no-input is set. If no ID is given the device type can be picked from a list.`,
+		Example: `aware device-type edit
+aware device-type edit 5cf717e2bec882982729dd8a --name "Protection Relay" --no-input`,
+		Aliases: []string{"update", "modify"},
+		Args:    cobra.MaximumNArgs(1),
+		Run:     edit,
+	}
+}
+
+// SetFlags set the flags supported by the edit command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("name", "", "Modified Name.")
+	cmd.Flags().String("kind", "", "Modified Kind.")
+	cmd.Flags().String("description", "", "Modified Description.")
+	cmd.Flags().Bool("no-input", false, "Disable prompt for non-required fields")
+}
+
+func edit(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	edit := editCmd{
+		client: client,
+		params: params,
+	}
+
+	if edit.params.ID == "" {
+		utils.ExitIfError(edit.setDeviceTypes())
+		utils.ExitIfError(edit.getDeviceTypeID())
+	}
+	utils.ExitIfError(edit.setDeviceType())
+
+	if !params.noInput {
+		edit.askQuestions()
+	}
+
+	t := edit.deviceType
+	if edit.params.name == "" {
+		edit.params.name = t.Name
+	}
+	if edit.params.kind == "" {
+		edit.params.kind = t.Kind
+	}
+	if edit.params.description == "" {
+		edit.params.description = t.Description
+	}
+
+	// The update replaces the device type so everything that isn't being edited is sent as it is
+	utils.ExitIfError(edit.client.UpdateDeviceTypeByID(t.ID, &aware.UpdateDeviceTypeRequest{
+		Name:              edit.params.name,
+		Kind:              edit.params.kind,
+		Description:       edit.params.description,
+		Organisation:      t.Organisation,
+		IsShared:          t.IsShared,
+		Options:           t.Options,
+		AllowedAttributes: t.AllowedAttributes,
+		Parameters:        t.Parameters,
+		DisplayGroups:     t.DisplayGroups,
+		Commands:          t.Commands,
+	}))
+
+	utils.Success("Device Type Updated")
+}
+
+func (e *editCmd) setDeviceType() error {
+	s := utils.ShowLoading(fmt.Sprintf("Fetching Device Type %s", e.params.ID))
+	defer s.Stop()
+
+	deviceType, err := e.client.GetDeviceTypeByID(e.params.ID)
+	if err != nil {
+		return err
+	}
+
+	e.deviceType = deviceType
+	return nil
+}
+
+func (e *editCmd) setDeviceTypes() error {
+	s := utils.ShowLoading("Fetching Device Types...")
+	defer s.Stop()
+
+	deviceTypes, err := e.client.GetAllDeviceTypes(viper.GetString("organisation"))
+	if err != nil {
+		return err
+	}
+
+	e.deviceTypes = deviceTypes
+	return nil
+}
+
+func (e *editCmd) getDeviceTypeID() error {
+	var ans string
+
+	options := make([]string, 0, len(e.deviceTypes))
+	for _, t := range e.deviceTypes {
+		options = append(options, t.ID+" - "+t.Name)
+	}
+
+	qs := &survey.Question{
+		Name: "id",
+		Prompt: &survey.Select{
+			Message: "Device Type:",
+			Options: options,
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return err
+	}
+
+	for i, t := range e.deviceTypes {
+		if ans == options[i] {
+			e.params.ID = t.ID
+			break
+		}
+	}
+
+	return nil
+}
+
+func (e *editCmd) askQuestions() {
+	utils.ExitIfError(e.ask("Change name?", "name", e.deviceType.Name, &e.params.name))
+	utils.ExitIfError(e.ask("Change kind?", "kind", e.deviceType.Kind, &e.params.kind))
+	utils.ExitIfError(e.ask("Change description?", "description", e.deviceType.Description, &e.params.description))
+}
+
+// ask asks for a new value of the field if it wasn't given as a flag, Ctrl+C keeps the current value.
+func (e *editCmd) ask(message, field, current string, value *string) error {
+	if *value != "" {
+		return nil
+	}
+
+	qs := &survey.Question{
+		Name: field,
+		Prompt: &survey.Input{
+			Message: message,
+			Default: current,
+			Help:    "Ctrl+C to skip question and leave as current",
+		},
+	}
+
+	var ans string
+	err := survey.Ask([]*survey.Question{qs}, &ans)
+	if err != nil {
+		if err == terminal.InterruptErr {
+			*value = current
+			utils.Success("Keeping %s: %s", field, current)
+			fmt.Println()
+			return nil
+		}
+		return err
+	}
+
+	*value = ans
+
+	return nil
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *editParams {
+	name, err := cmd.Flags().GetString("name")
+	utils.ExitIfError(err)
+
+	kind, err := cmd.Flags().GetString("kind")
+	utils.ExitIfError(err)
+
+	description, err := cmd.Flags().GetString("description")
+	utils.ExitIfError(err)
+
+	noInput, err := cmd.Flags().GetBool("no-input")
+	utils.ExitIfError(err)
+
+	var id string
+	if len(args) >= 1 {
+		id = args[0]
+	} else if noInput {
+		utils.Failed("Cannot use no-input without supplying device type ID")
+	}
+
+	return &editParams{
+		ID:          id,
+		name:        name,
+		kind:        kind,
+		description: description,
+		noInput:     noInput,
+	}
+}
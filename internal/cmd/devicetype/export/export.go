@@ -0,0 +1,92 @@
+// Package export contains the command for exporting a device type as a YAML definition.
+package export
+
+import (
+	"fmt"
+	"os"
+
+	"ampaware.com/cli/internal/cmd/devicetype/definition"
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type exportParams struct {
+	ID     string
+	output string
+}
+
+// NewCmdExport is the command for exporting a device type.
+func NewCmdExport() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export ID",
+		Short: "Export a device type as YAML",
+		Long: `Export a device type as a versioned YAML definition, which can be applied to
+another organisation or environment with apply.
+
+IDs are left out of the definition, parameters and everything else are referred to by name.`,
+		Example: `aware device-type export 5cf717e2bec882982729dd8a > ipb.yaml
+aware device-type export 5cf717e2bec882982729dd8a -o ipb.yaml`,
+		Args: cobra.ExactArgs(1),
+		Run:  export,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", "", "Write the definition to this file instead of stdout")
+}
+
+func export(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	deviceType, err := func() (*aware.DeviceType, error) {
+		s := utils.ShowLoading(fmt.Sprintf("Fetching Device Type %s", params.ID))
+		defer s.Stop()
+
+		return client.GetDeviceTypeByID(params.ID)
+	}()
+	utils.ExitIfError(err)
+
+	d := definition.FromDeviceType(deviceType)
+
+	if params.output == "" {
+		utils.ExitIfError(definition.Write(os.Stdout, d))
+		return
+	}
+
+	utils.ExitIfError(writeFile(params.output, d))
+	utils.Success("Device type written to %s", params.output)
+}
+
+func writeFile(file string, d *definition.Definition) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	if err := definition.Write(f, d); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *exportParams {
+	output, err := cmd.Flags().GetString("output")
+	utils.ExitIfError(err)
+
+	return &exportParams{
+		ID:     args[0],
+		output: output,
+	}
+}
@@ -0,0 +1,84 @@
+// Package list contains the command for listing all device types.
+package list
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type listParams struct {
+	plain     bool
+	noHeaders bool
+}
+
+// NewCmdList is the command for listing device types.
+func NewCmdList() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List device types in an organisation",
+		Long:  "List the device types available to an organisation with the number of parameters of each.",
+		Example: `aware device-type list
+aware device-type list --plain --no-headers`,
+		Aliases: []string{"lists", "ls"},
+		Run:     list,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("plain", false, "Display output in plain mode")
+	cmd.Flags().Bool("no-headers", false, "Don't display headers in plain mode. Works only with --plain")
+}
+
+func list(cmd *cobra.Command, _ []string) {
+	params := parseFlags(cmd)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	deviceTypes, err := func() ([]*aware.DeviceType, error) {
+		s := utils.ShowLoading("Fetching Device Types...")
+		defer s.Stop()
+
+		return client.GetAllDeviceTypes(viper.GetString("organisation"))
+	}()
+	utils.ExitIfError(err)
+
+	if len(deviceTypes) == 0 {
+		fmt.Println()
+		utils.Failed("No results found for given query")
+		return
+	}
+
+	v := view.DeviceTypeList{
+		DeviceTypes: deviceTypes,
+		Display: view.DeviceTypeDisplayFormat{
+			Plain:     params.plain,
+			NoHeaders: params.noHeaders,
+		},
+	}
+
+	utils.ExitIfError(v.Render())
+}
+
+func parseFlags(cmd *cobra.Command) *listParams {
+	plain, err := cmd.Flags().GetBool("plain")
+	utils.ExitIfError(err)
+
+	noHeaders, err := cmd.Flags().GetBool("no-headers")
+	utils.ExitIfError(err)
+
+	return &listParams{
+		plain:     plain,
+		noHeaders: noHeaders,
+	}
+}
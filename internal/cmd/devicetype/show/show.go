@@ -0,0 +1,107 @@
+// Package show contains the command for viewing a device type.
+package show
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type showCmd struct {
+	client      *aware.Client
+	ID          string
+	deviceTypes []*aware.DeviceType
+}
+
+// NewCmdView is the command for viewing a device type.
+func NewCmdView() *cobra.Command {
+	return &cobra.Command{
+		Use:   "view [ID]",
+		Short: "View a device type",
+		Long: `View the details of a device type and a table of its parameters.
+If no ID is given the device type can be picked from a list.`,
+		Example: "aware device-type view 5cf717e2bec882982729dd8a",
+		Aliases: []string{"show", "get"},
+		Args:    cobra.MaximumNArgs(1),
+		Run:     show,
+	}
+}
+
+func show(cmd *cobra.Command, args []string) {
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	sc := showCmd{
+		client: client,
+	}
+	if len(args) >= 1 {
+		sc.ID = args[0]
+	} else {
+		utils.ExitIfError(sc.setDeviceTypes())
+		utils.ExitIfError(sc.getDeviceType())
+	}
+
+	deviceType, err := func() (*aware.DeviceType, error) {
+		s := utils.ShowLoading(fmt.Sprintf("Fetching Device Type %s", sc.ID))
+		defer s.Stop()
+
+		return client.GetDeviceTypeByID(sc.ID)
+	}()
+	utils.ExitIfError(err)
+
+	v := view.DeviceTypeDetail{DeviceType: deviceType}
+	utils.ExitIfError(v.Render())
+}
+
+func (s *showCmd) setDeviceTypes() error {
+	sp := utils.ShowLoading("Fetching Device Types...")
+	defer sp.Stop()
+
+	deviceTypes, err := s.client.GetAllDeviceTypes(viper.GetString("organisation"))
+	if err != nil {
+		return err
+	}
+
+	s.deviceTypes = deviceTypes
+	return nil
+}
+
+func (s *showCmd) getDeviceType() error {
+	var ans string
+
+	options := make([]string, 0, len(s.deviceTypes))
+	for _, t := range s.deviceTypes {
+		options = append(options, t.ID+" - "+t.Name)
+	}
+
+	qs := &survey.Question{
+		Name: "id",
+		Prompt: &survey.Select{
+			Message: "Device Type:",
+			Options: options,
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return err
+	}
+
+	for i, t := range s.deviceTypes {
+		if ans == options[i] {
+			s.ID = t.ID
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+// Package create contains the command for creating a new entity.
+package create
+
+import (
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// noParent is the option for an entity without a parent.
+const noParent = "(None)"
+
+type createParams struct {
+	name         string
+	description  string
+	parentEntity string
+	organisation string
+	noInput      bool
+}
+
+type createCmd struct {
+	client         *aware.Client
+	params         *createParams
+	parentEntities []*aware.Entity
+}
+
+// NewCmdCreate is the create entity command.
+func NewCmdCreate() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create",
+		Short: "Create a new entity",
+		Long: `Create a new entity, optionally under a parent entity.
+Any details not given as flags are asked for, unless --no-input is set.`,
+		Example: `aware entity create
+aware entity create --name "Conveyor 3" --parent 5cf48c71b2f30979bc612292
+aware entity create --name Site --description "Main site" --no-input`,
+		Run: create,
+	}
+}
+
+// SetFlags sets the flags support by the create command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("name", "", "Set the Name.")
+	cmd.Flags().String("description", "", "Set the Description.")
+	cmd.Flags().String("parent", "", "Set the Parent Entity ID.")
+	cmd.Flags().Bool("no-input", false, "Disable prompt for non-required fields")
+}
+
+func create(cmd *cobra.Command, _ []string) {
+	params := parseFlags(cmd)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	cc := createCmd{
+		client: client,
+		params: params,
+	}
+
+	if cc.params.parentEntity == "" && !cc.params.noInput {
+		utils.ExitIfError(cc.setParentEntities())
+	}
+	utils.ExitIfError(cc.askQuestions())
+
+	if cc.params.name == "" {
+		utils.Failed("An entity requires a name")
+	}
+
+	ID, err := func() (string, error) {
+		s := utils.ShowLoading("Creating Entity...")
+		defer s.Stop()
+
+		resp, err := client.CreateEntity(&aware.CreateEntityRequest{
+			Name:         params.name,
+			Description:  params.description,
+			ParentEntity: params.parentEntity,
+			Organisation: params.organisation,
+		})
+		if err != nil {
+			return "", err
+		}
+		return resp.ID, nil
+	}()
+	utils.ExitIfError(err)
+	utils.Success("Entity created\n%s", ID)
+}
+
+func (c *createCmd) setParentEntities() error {
+	s := utils.ShowLoading("Fetching Entities...")
+	defer s.Stop()
+
+	parentEntities, err := c.client.GetAllEntities(c.params.organisation, aware.GetAllEntitiesOptions{})
+	if err != nil {
+		return err
+	}
+
+	c.parentEntities = parentEntities
+	return nil
+}
+
+func (c *createCmd) askQuestions() error {
+	var qs []*survey.Question
+
+	if c.params.name == "" {
+		qs = append(qs, &survey.Question{
+			Name:     "name",
+			Prompt:   &survey.Input{Message: "Name:"},
+			Validate: survey.Required,
+		})
+	}
+
+	if !c.params.noInput {
+		if c.params.description == "" {
+			qs = append(qs, &survey.Question{
+				Name:   "description",
+				Prompt: &survey.Input{Message: "Description:"},
+			})
+		}
+
+		if c.params.parentEntity == "" {
+			options := make([]string, 0, len(c.parentEntities)+1)
+			options = append(options, noParent)
+			for _, e := range c.parentEntities {
+				options = append(options, e.GetParentHierachyName())
+			}
+
+			qs = append(qs, &survey.Question{
+				Name: "parentEntity",
+				Prompt: &survey.Select{
+					Message: "Parent entity:",
+					Options: options,
+				},
+				Validate: survey.Required,
+			})
+		}
+	}
+
+	ans := struct {
+		Name         string
+		Description  string
+		ParentEntity string
+	}{}
+	if err := survey.Ask(qs, &ans); err != nil {
+		return err
+	}
+
+	if c.params.name == "" {
+		c.params.name = ans.Name
+	}
+	if c.params.description == "" {
+		c.params.description = ans.Description
+	}
+	if c.params.parentEntity == "" {
+		for _, e := range c.parentEntities {
+			if e.GetParentHierachyName() == ans.ParentEntity {
+				c.params.parentEntity = e.ID
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseFlags(cmd *cobra.Command) *createParams {
+	name, err := cmd.Flags().GetString("name")
+	utils.ExitIfError(err)
+
+	description, err := cmd.Flags().GetString("description")
+	utils.ExitIfError(err)
+
+	parentEntity, err := cmd.Flags().GetString("parent")
+	utils.ExitIfError(err)
+
+	noInput, err := cmd.Flags().GetBool("no-input")
+	utils.ExitIfError(err)
+
+	return &createParams{
+		name:         name,
+		description:  description,
+		parentEntity: parentEntity,
+		organisation: viper.GetString("organisation"),
+		noInput:      noInput,
+	}
+}
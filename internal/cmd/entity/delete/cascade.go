@@ -0,0 +1,126 @@
+package delete
+
+import (
+	"errors"
+	"fmt"
+
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/tree"
+)
+
+// subtree is an entity with the devices and entities under it, which are removed before it.
+type subtree struct {
+	entity   *aware.Entity
+	devices  []*aware.Device
+	children []*subtree
+}
+
+// failure is an item that could not be removed.
+type failure struct {
+	kind string
+	id   string
+	name string
+	err  error
+}
+
+func (f failure) String() string {
+	return fmt.Sprintf("%s %s (%s): %s", f.kind, f.name, f.id, f.err)
+}
+
+// newSubtree finds everything under the entity with the ID from every entity and device in the organisation.
+func newSubtree(id string, entities []*aware.Entity, devices []*aware.Device) (*subtree, error) {
+	var root *aware.Entity
+	children := make(map[string][]*aware.Entity)
+	for _, entity := range entities {
+		if entity.ID == id {
+			root = entity
+		}
+		if entity.ParentEntity != nil {
+			children[entity.ParentEntity.ID] = append(children[entity.ParentEntity.ID], entity)
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("entity %s not found", id)
+	}
+
+	attached := make(map[string][]*aware.Device)
+	for _, device := range devices {
+		attached[device.ParentEntity.ID] = append(attached[device.ParentEntity.ID], device)
+	}
+
+	// Visited guards against a cycle in the hierarchy walking forever
+	visited := make(map[string]struct{})
+	var build func(entity *aware.Entity) *subtree
+	build = func(entity *aware.Entity) *subtree {
+		visited[entity.ID] = struct{}{}
+
+		s := &subtree{entity: entity, devices: attached[entity.ID]}
+		for _, child := range children[entity.ID] {
+			if _, ok := visited[child.ID]; ok {
+				continue
+			}
+			s.children = append(s.children, build(child))
+		}
+		return s
+	}
+
+	return build(root), nil
+}
+
+// count returns the number of entities and devices in the subtree, including the entity itself.
+func (s *subtree) count() (entities, devices int) {
+	entities, devices = 1, len(s.devices)
+	for _, child := range s.children {
+		e, d := child.count()
+		entities += e
+		devices += d
+	}
+	return entities, devices
+}
+
+// node returns the subtree for previewing what will be removed.
+func (s *subtree) node() *tree.Node {
+	n := &tree.Node{Label: s.entity.Name, Detail: "(" + s.entity.ID + ")"}
+	for _, device := range s.devices {
+		n.Children = append(n.Children, &tree.Node{
+			Label:  "device " + device.DisplayName,
+			Detail: "(" + device.ID + ")",
+		})
+	}
+	for _, child := range s.children {
+		n.Children = append(n.Children, child.node())
+	}
+	return n
+}
+
+// remove deletes the subtree bottom-up, devices and child entities before the entity they are under.
+// An entity is kept if anything under it could not be removed, so nothing is left without a parent.
+// done is called after each item, whether it was removed or not.
+func (s *subtree) remove(deleteDevice, deleteEntity func(id string) error, done func()) []failure {
+	var failures []failure
+
+	for _, device := range s.devices {
+		if err := deleteDevice(device.ID); err != nil {
+			failures = append(failures, failure{kind: "device", id: device.ID, name: device.DisplayName, err: err})
+		}
+		done()
+	}
+
+	for _, child := range s.children {
+		failures = append(failures, child.remove(deleteDevice, deleteEntity, done)...)
+	}
+
+	if len(failures) > 0 {
+		failures = append(failures, failure{
+			kind: "entity",
+			id:   s.entity.ID,
+			name: s.entity.Name,
+			err:  errors.New("kept as something under it could not be removed"),
+		})
+	} else if err := deleteEntity(s.entity.ID); err != nil {
+		failures = append(failures, failure{kind: "entity", id: s.entity.ID, name: s.entity.Name, err: err})
+	}
+	done()
+
+	return failures
+}
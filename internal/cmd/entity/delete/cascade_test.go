@@ -0,0 +1,94 @@
+package delete
+
+import (
+	"errors"
+	"testing"
+
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/tree"
+	"github.com/matryer/is"
+)
+
+func hierarchy() ([]*aware.Entity, []*aware.Device) {
+	site := &aware.Entity{ID: "SITE", Name: "Site"}
+	line1 := &aware.Entity{ID: "LINE-1", Name: "Line 1", ParentEntity: site}
+	line2 := &aware.Entity{ID: "LINE-2", Name: "Line 2", ParentEntity: site}
+	motor := &aware.Entity{ID: "MOTOR", Name: "Motor", ParentEntity: line1}
+	office := &aware.Entity{ID: "OFFICE", Name: "Office"}
+
+	devices := []*aware.Device{
+		{ID: "DEV-1", DisplayName: "Relay", ParentEntity: *motor},
+		{ID: "DEV-2", DisplayName: "Meter", ParentEntity: *line2},
+		{ID: "DEV-3", DisplayName: "Camera", ParentEntity: *office},
+	}
+
+	return []*aware.Entity{site, line1, line2, motor, office}, devices
+}
+
+func TestNewSubtree(t *testing.T) {
+	is := is.New(t)
+
+	entities, devices := hierarchy()
+
+	root, err := newSubtree("LINE-1", entities, devices)
+	is.NoErr(err)
+
+	e, d := root.count()
+	is.Equal(e, 2)
+	is.Equal(d, 1)
+	is.Equal(tree.Render([]*tree.Node{root.node()}), `Line 1 (LINE-1)
+└── Motor (MOTOR)
+    └── device Relay (DEV-1)
+`)
+
+	_, err = newSubtree("MISSING", entities, devices)
+	is.Equal(err.Error(), "entity MISSING not found")
+}
+
+func TestSubtreeRemove(t *testing.T) {
+	is := is.New(t)
+
+	entities, devices := hierarchy()
+	root, err := newSubtree("SITE", entities, devices)
+	is.NoErr(err)
+
+	var removed []string
+	remove := func(id string) error {
+		removed = append(removed, id)
+		return nil
+	}
+
+	var done int
+	failures := root.remove(remove, remove, func() { done++ })
+	is.Equal(len(failures), 0)
+	is.Equal(removed, []string{"DEV-1", "MOTOR", "LINE-1", "DEV-2", "LINE-2", "SITE"}) // Bottom-up
+	is.Equal(done, 6)
+}
+
+func TestSubtreeRemoveFailure(t *testing.T) {
+	is := is.New(t)
+
+	entities, devices := hierarchy()
+	root, err := newSubtree("SITE", entities, devices)
+	is.NoErr(err)
+
+	var removed []string
+	remove := func(id string) error {
+		if id == "DEV-1" {
+			return errors.New("in use")
+		}
+		removed = append(removed, id)
+		return nil
+	}
+
+	var done int
+	failures := root.remove(remove, remove, func() { done++ })
+	is.Equal(removed, []string{"DEV-2", "LINE-2"}) // Only the branch without the failure is removed
+	is.Equal(done, 6)
+
+	is.Equal(len(failures), 4)
+	is.Equal(failures[0].String(), "device Relay (DEV-1): in use")
+	is.Equal(failures[1].id, "MOTOR")
+	is.Equal(failures[2].id, "LINE-1")
+	is.Equal(failures[3].id, "SITE")
+}
@@ -0,0 +1,235 @@
+// Package delete contains the command for deleting an entity.
+package delete
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/tree"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type deleteParams struct {
+	ID      string
+	force   bool
+	cascade bool
+}
+
+type deleteCommand struct {
+	client   *aware.Client
+	params   *deleteParams
+	entities []*aware.Entity
+	devices  []*aware.Device
+}
+
+// NewCmdDelete is the delete entity command.
+func NewCmdDelete() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [ID]",
+		Short: "Delete an entity",
+		Long: `Delete an entity. If no ID is given the entity can be picked from a list.
+The deletion is confirmed first unless --force is set.
+
+With --cascade every entity and device under the entity is removed too. Everything
+that will be removed is shown first, then it is removed from the bottom up so an
+entity is only removed once everything under it has been. If anything can't be
+removed the entities above it are kept and listed with the failures.`,
+		Example: `aware entity delete 5cf48c71b2f30979bc612292
+aware entity delete 5cf48c71b2f30979bc612292 --force
+aware entity delete 5cf48c71b2f30979bc612292 --cascade`,
+		Aliases: []string{"remove", "rm", "del"},
+		Args:    cobra.MaximumNArgs(1),
+		Run:     del,
+	}
+}
+
+func del(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	del := deleteCommand{
+		client: client,
+		params: params,
+	}
+
+	if del.params.ID == "" {
+		utils.ExitIfError(del.setEntities())
+		utils.ExitIfError(del.getEntityID())
+	}
+
+	if del.params.cascade {
+		del.cascade()
+		return
+	}
+
+	if !del.params.force {
+		var confirm bool
+
+		qs := &survey.Question{
+			Name:     "id",
+			Prompt:   &survey.Confirm{Message: fmt.Sprintf("Are you sure you want to delete %s?", del.params.ID)},
+			Validate: survey.Required,
+		}
+
+		if err := survey.Ask([]*survey.Question{qs}, &confirm); err != nil {
+			utils.ExitIfError(err)
+		}
+
+		if !confirm {
+			return
+		}
+	}
+
+	err := func() error {
+		s := utils.ShowLoading(fmt.Sprintf("Removing Entity %s", del.params.ID))
+		defer s.Stop()
+
+		return del.client.DeleteEntity(del.params.ID)
+	}()
+	utils.ExitIfError(err)
+
+	utils.Success("Entity removed successfully\n%s", del.params.ID)
+}
+
+// SetFlags set the flags supported by the the delete command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("force", "f", false, "Force the deletion of the entity.")
+	cmd.Flags().Bool("cascade", false, "Also delete every entity and device under the entity.")
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *deleteParams {
+	force, err := cmd.Flags().GetBool("force")
+	utils.ExitIfError(err)
+
+	cascade, err := cmd.Flags().GetBool("cascade")
+	utils.ExitIfError(err)
+
+	var id string
+	if len(args) >= 1 {
+		id = args[0]
+	}
+
+	return &deleteParams{
+		ID:      id,
+		force:   force,
+		cascade: cascade,
+	}
+}
+
+// cascade deletes the entity and everything under it.
+func (d *deleteCommand) cascade() {
+	utils.ExitIfError(d.setSubtreeItems())
+
+	root, err := newSubtree(d.params.ID, d.entities, d.devices)
+	utils.ExitIfError(err)
+
+	entities, devices := root.count()
+	fmt.Print(tree.Render([]*tree.Node{root.node()}))
+	fmt.Println()
+
+	if !d.params.force {
+		var confirm bool
+
+		qs := &survey.Question{
+			Name:     "id",
+			Prompt:   &survey.Confirm{Message: fmt.Sprintf("Are you sure you want to delete %d entities and %d devices?", entities, devices)},
+			Validate: survey.Required,
+		}
+
+		if err := survey.Ask([]*survey.Question{qs}, &confirm); err != nil {
+			utils.ExitIfError(err)
+		}
+
+		if !confirm {
+			return
+		}
+	}
+
+	bar := utils.ShowProgress("Removing", entities+devices)
+	failures := root.remove(d.client.DeleteDevice, d.client.DeleteEntity, func() { bar.Increment(1) })
+	bar.Stop()
+
+	if len(failures) > 0 {
+		for _, f := range failures {
+			utils.Fail("%s", f)
+		}
+		utils.Failed("Removed %d of %d items", entities+devices-len(failures), entities+devices)
+	}
+
+	utils.Success("Removed %d entities and %d devices\n%s", entities, devices, d.params.ID)
+}
+
+func (d *deleteCommand) setEntities() error {
+	s := utils.ShowLoading("Fetching Entities...")
+	defer s.Stop()
+
+	entities, err := d.client.GetAllEntities(viper.GetString("organisation"), aware.GetAllEntitiesOptions{})
+	if err != nil {
+		return err
+	}
+
+	d.entities = entities
+	return nil
+}
+
+// setSubtreeItems fetches every entity and device, including inactive ones, so none are left behind.
+func (d *deleteCommand) setSubtreeItems() error {
+	s := utils.ShowLoading("Fetching Entities and Devices...")
+	defer s.Stop()
+
+	org := viper.GetString("organisation")
+
+	entities, err := d.client.GetAllEntities(org, aware.GetAllEntitiesOptions{IncludeInactive: true})
+	if err != nil {
+		return err
+	}
+
+	devices, err := d.client.GetAllDevices(aware.GetAllDevicesOptions{OrganisationID: org, IncludeInactive: true})
+	if err != nil {
+		return err
+	}
+
+	d.entities = entities
+	d.devices = devices
+	return nil
+}
+
+func (d *deleteCommand) getEntityID() error {
+	var ans string
+
+	options := make([]string, 0, len(d.entities))
+	for _, entity := range d.entities {
+		options = append(options, entity.ID+" - "+entity.GetParentHierachyName())
+	}
+
+	qs := &survey.Question{
+		Name: "id",
+		Prompt: &survey.Select{
+			Message: "Entity:",
+			Options: options,
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return err
+	}
+
+	for i, entity := range d.entities {
+		if ans == options[i] {
+			d.params.ID = entity.ID
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+// Package edit contains the command for editing an existing entity.
+package edit
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// noParent is the option for an entity without a parent.
+const noParent = "(None)"
+
+type editParams struct {
+	ID           string
+	name         string
+	description  string
+	parentEntity string
+	noInput      bool
+}
+
+type editCmd struct {
+	client   *aware.Client
+	params   *editParams
+	entity   *aware.Entity
+	entities []*aware.Entity
+	noParent bool // Whether the entity is moved to the top of the hierarchy
+}
+
+// NewCmdEdit is the edit entity command.
+func NewCmdEdit() *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit [ID]",
+		Short: "Edit an entity",
+		Long: `Edit the name, description or parent of an entity.
+Any details not given as flags are asked for with the current value as the default,
+unless --no-input is set. If no ID is given the entity can be picked from a list.`,
+		Example: `aware entity edit
+aware entity edit 5cf48c71b2f30979bc612292 --name "Conveyor 4"
+aware entity edit 5cf48c71b2f30979bc612292 --parent 5cf48c71b2f30979bc612293 --no-input`,
+		Aliases: []string{"update", "modify"},
+		Args:    cobra.MaximumNArgs(1),
+		Run:     edit,
+	}
+}
+
+// SetFlags set the flags supported by the edit command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("name", "", "Modified Name.")
+	cmd.Flags().String("description", "", "Modified Description.")
+	cmd.Flags().String("parent", "", "Modified Parent Entity ID.")
+	cmd.Flags().Bool("no-input", false, "Disable prompt for non-required fields")
+}
+
+func edit(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	edit := editCmd{
+		client: client,
+		params: params,
+	}
+
+	if params.noInput {
+		utils.ExitIfError(edit.setEntity())
+	} else {
+		utils.ExitIfError(edit.setEntities())
+		if edit.params.ID == "" {
+			utils.ExitIfError(edit.getEntity())
+		} else {
+			utils.ExitIfError(edit.setEntity())
+		}
+
+		edit.askQuestions()
+	}
+
+	if edit.params.name == "" {
+		edit.params.name = edit.entity.Name
+	}
+	if edit.params.description == "" {
+		edit.params.description = edit.entity.Description
+	}
+	if edit.params.parentEntity == "" && edit.entity.ParentEntity != nil && !edit.noParent {
+		edit.params.parentEntity = edit.entity.ParentEntity.ID
+	}
+
+	if edit.params.parentEntity == edit.entity.ID {
+		utils.Failed("An entity can't be its own parent")
+	}
+
+	utils.ExitIfError(edit.client.UpdateEntityByID(edit.entity.ID, &aware.UpdateEntityRequest{
+		Name:         edit.params.name,
+		Description:  edit.params.description,
+		ParentEntity: edit.params.parentEntity,
+		Organisation: edit.entity.Organisation,
+	}))
+
+	utils.Success("Entity Updated")
+}
+
+func (e *editCmd) setEntity() error {
+	s := utils.ShowLoading(fmt.Sprintf("Fetching Entity %s", e.params.ID))
+	defer s.Stop()
+
+	entity, err := e.client.GetEntityByID(e.params.ID)
+	if err != nil {
+		return err
+	}
+
+	e.entity = entity
+	return nil
+}
+
+func (e *editCmd) setEntities() error {
+	s := utils.ShowLoading("Fetching Entities...")
+	defer s.Stop()
+
+	entities, err := e.client.GetAllEntities(viper.GetString("organisation"), aware.GetAllEntitiesOptions{})
+	if err != nil {
+		return err
+	}
+
+	e.entities = entities
+	return nil
+}
+
+func (e *editCmd) getEntity() error {
+	var ans string
+
+	options := make([]string, 0, len(e.entities))
+	for _, entity := range e.entities {
+		options = append(options, entity.ID+" - "+entity.GetParentHierachyName())
+	}
+
+	qs := &survey.Question{
+		Name: "id",
+		Prompt: &survey.Select{
+			Message: "Entity:",
+			Options: options,
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return err
+	}
+
+	for i, entity := range e.entities {
+		if ans == options[i] {
+			e.params.ID = entity.ID
+			e.entity = entity
+			break
+		}
+	}
+
+	return nil
+}
+
+func (e *editCmd) askQuestions() {
+	utils.ExitIfError(e.getName())
+	utils.ExitIfError(e.getDescription())
+	utils.ExitIfError(e.getParentEntity())
+}
+
+func (e *editCmd) getName() error {
+	if e.params.name != "" {
+		return nil
+	}
+
+	qs := &survey.Question{
+		Name: "name",
+		Prompt: &survey.Input{
+			Message: "Change name?",
+			Default: e.entity.Name,
+			Help:    "Ctrl+C to skip question and leave as current",
+		},
+	}
+
+	ans := struct{ Name string }{}
+	err := survey.Ask([]*survey.Question{qs}, &ans)
+	if err != nil {
+		if err == terminal.InterruptErr {
+			e.params.name = e.entity.Name
+			utils.Success("Keeping name: %s", e.entity.Name)
+			fmt.Println()
+			return nil
+		}
+		return err
+	}
+
+	e.params.name = ans.Name
+
+	return nil
+}
+
+func (e *editCmd) getDescription() error {
+	if e.params.description != "" {
+		return nil
+	}
+
+	qs := &survey.Question{
+		Name: "description",
+		Prompt: &survey.Input{
+			Message: "Change description?",
+			Default: e.entity.Description,
+			Help:    "Ctrl+C to skip question and leave as current",
+		},
+	}
+
+	ans := struct{ Description string }{}
+	err := survey.Ask([]*survey.Question{qs}, &ans)
+	if err != nil {
+		if err == terminal.InterruptErr {
+			e.params.description = e.entity.Description
+			utils.Success("Keeping description: %s", e.entity.Description)
+			fmt.Println()
+			return nil
+		}
+		return err
+	}
+
+	e.params.description = ans.Description
+
+	return nil
+}
+
+func (e *editCmd) getParentEntity() error {
+	if e.params.parentEntity != "" {
+		return nil
+	}
+
+	current := noParent
+	if e.entity.ParentEntity != nil {
+		current = e.entity.ParentEntity.GetParentHierachyName()
+	}
+
+	options := make([]string, 0, len(e.entities)+1)
+	options = append(options, noParent)
+	for _, t := range e.entities {
+		// An entity can't be moved under itself
+		if t.ID != e.entity.ID {
+			options = append(options, t.GetParentHierachyName())
+		}
+	}
+
+	qs := &survey.Question{
+		Name: "parentEntity",
+		Prompt: &survey.Select{
+			Message: fmt.Sprintf("Change parent entity? (Currently: %s)", current),
+			Options: options,
+			Default: current,
+			Help:    "Ctrl+C to skip question and leave as current",
+			Description: func(value string, index int) string {
+				if value == current {
+					return "Current"
+				}
+				return ""
+			},
+		},
+	}
+
+	ans := struct{ ParentEntity string }{}
+	err := survey.Ask([]*survey.Question{qs}, &ans)
+	if err != nil {
+		if err == terminal.InterruptErr {
+			utils.Success("Keeping parent entity: %s", current)
+			fmt.Println()
+			return nil
+		}
+		return err
+	}
+
+	e.noParent = ans.ParentEntity == noParent
+	for _, t := range e.entities {
+		if t.GetParentHierachyName() == ans.ParentEntity {
+			e.params.parentEntity = t.ID
+			break
+		}
+	}
+
+	return nil
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *editParams {
+	name, err := cmd.Flags().GetString("name")
+	utils.ExitIfError(err)
+
+	description, err := cmd.Flags().GetString("description")
+	utils.ExitIfError(err)
+
+	parentEntity, err := cmd.Flags().GetString("parent")
+	utils.ExitIfError(err)
+
+	noInput, err := cmd.Flags().GetBool("no-input")
+	utils.ExitIfError(err)
+
+	var id string
+	if len(args) >= 1 {
+		id = args[0]
+	} else if noInput {
+		utils.Failed("Cannot use no-input without supplying entity ID")
+	}
+
+	return &editParams{
+		ID:           id,
+		name:         name,
+		description:  description,
+		parentEntity: parentEntity,
+		noInput:      noInput,
+	}
+}
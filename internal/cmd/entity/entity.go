@@ -0,0 +1,52 @@
+// Package entity contains the root command for all entity commands.
+package entity
+
+import (
+	"ampaware.com/cli/internal/cmd/entity/create"
+	"ampaware.com/cli/internal/cmd/entity/delete"
+	"ampaware.com/cli/internal/cmd/entity/edit"
+	"ampaware.com/cli/internal/cmd/entity/list"
+	"ampaware.com/cli/internal/cmd/entity/move"
+	"ampaware.com/cli/internal/cmd/entity/show"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdEntity is the root command for entity.
+func NewCmdEntity() *cobra.Command {
+	cmd := cobra.Command{
+		Use:         "entity",
+		Short:       "Manage Entities in an Organisation",
+		Long:        "Manage the Entities in an Organisation, which devices and other entities are placed under.",
+		Aliases:     []string{"entities"},
+		Annotations: map[string]string{},
+		RunE:        entity,
+	}
+
+	lc := list.NewCmdList()
+	sh := show.NewCmdView()
+	cr := create.NewCmdCreate()
+	ed := edit.NewCmdEdit()
+	de := delete.NewCmdDelete()
+	mv := move.NewCmdMove()
+
+	cmd.AddCommand(
+		lc,
+		sh,
+		cr,
+		ed,
+		de,
+		mv,
+	)
+
+	list.SetFlags(lc)
+	create.SetFlags(cr)
+	edit.SetFlags(ed)
+	delete.SetFlags(de)
+	move.SetFlags(mv)
+
+	return &cmd
+}
+
+func entity(cmd *cobra.Command, _ []string) error {
+	return cmd.Help()
+}
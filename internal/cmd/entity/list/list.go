@@ -0,0 +1,116 @@
+// Package list contains the command for listing all entities.
+package list
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type listParams struct {
+	tree      bool
+	plain     bool
+	noHeaders bool
+}
+
+// NewCmdList is the command for listing entities.
+func NewCmdList() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List entities in an organisation",
+		Long: `List the entities in an organisation with the number of devices under each.
+
+With --tree the entities are shown as their hierarchy, which can be expanded and
+collapsed, or as indented text with --plain.`,
+		Example: `aware entity list
+aware entity list --plain --no-headers
+aware entity list --tree
+aware entity list --tree --plain`,
+		Aliases: []string{"lists", "ls"},
+		Run:     list,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("tree", false, "Display the entities as a hierarchy")
+	cmd.Flags().Bool("plain", false, "Display output in plain mode")
+	cmd.Flags().Bool("no-headers", false, "Don't display headers in plain mode. Works only with --plain")
+}
+
+func list(cmd *cobra.Command, _ []string) {
+	params := parseFlags(cmd)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	entities, counts, err := func() ([]*aware.Entity, map[string]int, error) {
+		s := utils.ShowLoading("Fetching Entities...")
+		defer s.Stop()
+
+		org := viper.GetString("organisation")
+
+		entities, err := client.GetAllEntities(org, aware.GetAllEntitiesOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		devices, err := client.GetAllDevices(aware.GetAllDevicesOptions{OrganisationID: org})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		counts := make(map[string]int)
+		for _, device := range devices {
+			counts[device.ParentEntity.ID]++
+		}
+
+		return entities, counts, nil
+	}()
+	utils.ExitIfError(err)
+
+	if len(entities) == 0 {
+		fmt.Println()
+		utils.Failed("No results found for given query")
+		return
+	}
+
+	display := view.EntityDisplayFormat{
+		Plain:     params.plain,
+		NoHeaders: params.noHeaders,
+	}
+
+	if params.tree {
+		v := view.EntityTree{Entities: entities, DeviceCounts: counts, Display: display}
+		utils.ExitIfError(v.Render())
+		return
+	}
+
+	v := view.EntityList{Entities: entities, DeviceCounts: counts, Display: display}
+	utils.ExitIfError(v.Render())
+}
+
+func parseFlags(cmd *cobra.Command) *listParams {
+	tree, err := cmd.Flags().GetBool("tree")
+	utils.ExitIfError(err)
+
+	plain, err := cmd.Flags().GetBool("plain")
+	utils.ExitIfError(err)
+
+	noHeaders, err := cmd.Flags().GetBool("no-headers")
+	utils.ExitIfError(err)
+
+	return &listParams{
+		tree:      tree,
+		plain:     plain,
+		noHeaders: noHeaders,
+	}
+}
@@ -0,0 +1,252 @@
+// Package move contains the command for moving entities to another parent entity.
+package move
+
+import (
+	"fmt"
+	"os"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type moveParams struct {
+	IDs      []string
+	to       string
+	entityID string
+	stdin    bool
+	force    bool
+}
+
+type moveCmd struct {
+	client   *aware.Client
+	params   *moveParams
+	target   *aware.Entity
+	entities []*aware.Entity
+}
+
+// NewCmdMove is the command for moving entities to another parent entity.
+func NewCmdMove() *cobra.Command {
+	return &cobra.Command{
+		Use:   "move [ID...] --to ENTITY",
+		Short: "Move entities to another parent entity",
+		Long: `Move entities, with everything under them, to another parent entity.
+
+The entities are given by ID, by --entity for every entity directly under it, or as IDs
+on stdin with one per line. An entity can't be moved under itself or anything under it.
+The move is confirmed first unless --force is set, which is required when reading from stdin.`,
+		Example: `aware entity move 5cf48c71b2f30979bc612292 --to 5cf48c71b2f30979bc612291
+aware entity move --entity 5cf48c71b2f30979bc612290 --to 5cf48c71b2f30979bc612291
+aware entity list --plain --no-headers | aware entity move --to 5cf48c71b2f30979bc612291 --force`,
+		Aliases: []string{"mv"},
+		Run:     move,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("to", "", "ID of the entity to move the entities under")
+	cmd.Flags().String("entity", "", "Move every entity directly under the given entity")
+	cmd.Flags().BoolP("force", "f", false, "Move the entities without confirming")
+}
+
+func move(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	mc := moveCmd{
+		client: client,
+		params: params,
+	}
+
+	utils.ExitIfError(mc.setEntities())
+
+	if len(mc.entities) == 0 {
+		utils.Warn("No entities to move")
+		return
+	}
+
+	if !mc.params.force {
+		for _, entity := range mc.entities {
+			fmt.Printf("%s\t%s -> %s\n", entity.ID, entity.GetParentHierachyName(), mc.target.GetParentHierachyName()+" -> "+entity.Name)
+		}
+		fmt.Println()
+
+		var confirm bool
+
+		qs := &survey.Question{
+			Name:     "confirm",
+			Prompt:   &survey.Confirm{Message: fmt.Sprintf("Are you sure you want to move %d entities?", len(mc.entities))},
+			Validate: survey.Required,
+		}
+
+		if err := survey.Ask([]*survey.Question{qs}, &confirm); err != nil {
+			utils.ExitIfError(err)
+		}
+
+		if !confirm {
+			return
+		}
+	}
+
+	var failures []string
+	bar := utils.ShowProgress("Moving", len(mc.entities))
+	for _, entity := range mc.entities {
+		// The update replaces every field so the unchanged ones are sent as they are
+		err := mc.client.UpdateEntityByID(entity.ID, &aware.UpdateEntityRequest{
+			Name:         entity.Name,
+			Description:  entity.Description,
+			ParentEntity: mc.target.ID,
+			Organisation: entity.Organisation,
+		})
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%s): %s", entity.Name, entity.ID, err))
+		}
+		bar.Increment(1)
+	}
+	bar.Stop()
+
+	for _, failure := range failures {
+		utils.Fail("%s", failure)
+	}
+
+	if failed := len(failures); failed > 0 {
+		utils.Failed("Moved %d of %d entities", len(mc.entities)-failed, len(mc.entities))
+	}
+
+	utils.Success("Moved %d entities to %s", len(mc.entities), mc.target.GetParentHierachyName())
+}
+
+func (m *moveCmd) setEntities() error {
+	if m.params.stdin {
+		ids, err := utils.ReadIDs(os.Stdin)
+		if err != nil {
+			return err
+		}
+		m.params.IDs = append(m.params.IDs, ids...)
+	}
+
+	s := utils.ShowLoading("Fetching Entities...")
+	defer s.Stop()
+
+	all, err := m.client.GetAllEntities(viper.GetString("organisation"), aware.GetAllEntitiesOptions{IncludeInactive: true})
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*aware.Entity, len(all))
+	for _, entity := range all {
+		byID[entity.ID] = entity
+	}
+
+	target, ok := byID[m.params.to]
+	if !ok {
+		return fmt.Errorf("entity %s not found", m.params.to)
+	}
+	m.target = target
+
+	var entities []*aware.Entity
+	for _, id := range m.params.IDs {
+		entity, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("entity %s not found", id)
+		}
+		entities = append(entities, entity)
+	}
+	if m.params.entityID != "" {
+		for _, entity := range all {
+			if entity.ParentEntity != nil && entity.ParentEntity.ID == m.params.entityID {
+				entities = append(entities, entity)
+			}
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, entity := range entities {
+		if _, ok := seen[entity.ID]; ok {
+			continue
+		}
+		seen[entity.ID] = struct{}{}
+
+		if entity.ParentEntity != nil && entity.ParentEntity.ID == target.ID {
+			continue
+		}
+		if err := checkMove(all, entity.ID, target.ID); err != nil {
+			return err
+		}
+		m.entities = append(m.entities, entity)
+	}
+
+	return nil
+}
+
+// checkMove checks that moving the entity under the target doesn't make a cycle,
+// which happens when the target is the entity or anything under it.
+func checkMove(entities []*aware.Entity, id, to string) error {
+	parents := make(map[string]string, len(entities))
+	names := make(map[string]string, len(entities))
+	for _, entity := range entities {
+		names[entity.ID] = entity.Name
+		if entity.ParentEntity != nil {
+			parents[entity.ID] = entity.ParentEntity.ID
+		}
+	}
+
+	if id == to {
+		return fmt.Errorf("entity %s can't be moved under itself", names[id])
+	}
+
+	// Visited guards against a cycle already in the hierarchy
+	visited := make(map[string]struct{})
+	for e, ok := parents[to]; ok; e, ok = parents[e] {
+		if _, seen := visited[e]; seen {
+			break
+		}
+		visited[e] = struct{}{}
+
+		if e == id {
+			return fmt.Errorf("entity %s can't be moved under %s as it is under %s", names[id], names[to], names[id])
+		}
+	}
+
+	return nil
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *moveParams {
+	to, err := cmd.Flags().GetString("to")
+	utils.ExitIfError(err)
+
+	entityID, err := cmd.Flags().GetString("entity")
+	utils.ExitIfError(err)
+
+	force, err := cmd.Flags().GetBool("force")
+	utils.ExitIfError(err)
+
+	if to == "" {
+		utils.Failed("The entity to move to is required, set it with --to")
+	}
+
+	stdin := utils.StdinHasData()
+	if len(args) == 0 && !stdin && entityID == "" {
+		utils.Failed("Give the entities to move as IDs, with --entity, or on stdin")
+	}
+	if stdin && !force {
+		utils.Failed("--force is required when reading IDs from stdin")
+	}
+
+	return &moveParams{
+		IDs:      args,
+		to:       to,
+		entityID: entityID,
+		stdin:    stdin,
+		force:    force,
+	}
+}
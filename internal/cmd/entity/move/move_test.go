@@ -0,0 +1,28 @@
+package move
+
+import (
+	"testing"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+func TestCheckMove(t *testing.T) {
+	is := is.New(t)
+
+	site := &aware.Entity{ID: "SITE", Name: "Site"}
+	line := &aware.Entity{ID: "LINE", Name: "Line", ParentEntity: &aware.Entity{ID: "SITE"}}
+	motor := &aware.Entity{ID: "MOTOR", Name: "Motor", ParentEntity: &aware.Entity{ID: "LINE"}}
+	office := &aware.Entity{ID: "OFFICE", Name: "Office"}
+	entities := []*aware.Entity{site, line, motor, office}
+
+	is.NoErr(checkMove(entities, "MOTOR", "SITE"))
+	is.NoErr(checkMove(entities, "LINE", "OFFICE"))
+	is.NoErr(checkMove(entities, "OFFICE", "MOTOR"))
+
+	err := checkMove(entities, "LINE", "LINE")
+	is.Equal(err.Error(), "entity Line can't be moved under itself")
+
+	err = checkMove(entities, "SITE", "MOTOR")
+	is.Equal(err.Error(), "entity Site can't be moved under Motor as it is under Site")
+}
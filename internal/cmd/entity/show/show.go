@@ -0,0 +1,133 @@
+// Package show contains the command for viewing an entity.
+package show
+
+import (
+	"fmt"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/internal/view"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type showCmd struct {
+	client   *aware.Client
+	ID       string
+	entities []*aware.Entity
+}
+
+// NewCmdView is the command for viewing an entity.
+func NewCmdView() *cobra.Command {
+	return &cobra.Command{
+		Use:   "view [ID]",
+		Short: "View an entity",
+		Long: `View the details of an entity, its children and the devices directly under it.
+If no ID is given the entity can be picked from a list.`,
+		Example: "aware entity view 5cf48c71b2f30979bc612292",
+		Aliases: []string{"show", "get"},
+		Args:    cobra.MaximumNArgs(1),
+		Run:     show,
+	}
+}
+
+func show(cmd *cobra.Command, args []string) {
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	sc := showCmd{
+		client: client,
+	}
+	if len(args) >= 1 {
+		sc.ID = args[0]
+	}
+
+	utils.ExitIfError(sc.setEntities())
+	if sc.ID == "" {
+		utils.ExitIfError(sc.getEntity())
+	}
+
+	v, err := func() (*view.EntityDetail, error) {
+		s := utils.ShowLoading(fmt.Sprintf("Fetching Entity %s", sc.ID))
+		defer s.Stop()
+
+		entity, err := client.GetEntityByID(sc.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		devices, err := client.GetAllDevices(aware.GetAllDevicesOptions{
+			OrganisationID: viper.GetString("organisation"),
+			EntityID:       sc.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		v := &view.EntityDetail{Entity: entity}
+		for _, device := range devices {
+			if device.ParentEntity.ID == sc.ID {
+				v.Devices = append(v.Devices, device)
+			}
+		}
+		for _, e := range sc.entities {
+			if e.ParentEntity != nil && e.ParentEntity.ID == sc.ID {
+				v.Children = append(v.Children, e)
+			}
+		}
+
+		return v, nil
+	}()
+	utils.ExitIfError(err)
+
+	utils.ExitIfError(v.Render())
+}
+
+func (s *showCmd) setEntities() error {
+	sp := utils.ShowLoading("Fetching Entities...")
+	defer sp.Stop()
+
+	entities, err := s.client.GetAllEntities(viper.GetString("organisation"), aware.GetAllEntitiesOptions{})
+	if err != nil {
+		return err
+	}
+
+	s.entities = entities
+	return nil
+}
+
+func (s *showCmd) getEntity() error {
+	var ans string
+
+	options := make([]string, 0, len(s.entities))
+	for _, entity := range s.entities {
+		options = append(options, entity.ID+" - "+entity.GetParentHierachyName())
+	}
+
+	qs := &survey.Question{
+		Name: "id",
+		Prompt: &survey.Select{
+			Message: "Entity:",
+			Options: options,
+		},
+		Validate: survey.Required,
+	}
+
+	if err := survey.Ask([]*survey.Question{qs}, &ans); err != nil {
+		return err
+	}
+
+	for i, entity := range s.entities {
+		if ans == options[i] {
+			s.ID = entity.ID
+			break
+		}
+	}
+
+	return nil
+}
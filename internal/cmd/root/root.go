@@ -9,7 +9,10 @@ import (
 	"github.com/spf13/viper"
 
 	"ampaware.com/cli/internal/cmd/device"
+	"ampaware.com/cli/internal/cmd/devicetype"
+	"ampaware.com/cli/internal/cmd/entity"
 	initCmd "ampaware.com/cli/internal/cmd/init"
+	"ampaware.com/cli/internal/cmd/telemetry"
 	awareConfig "ampaware.com/cli/internal/config"
 	"ampaware.com/cli/internal/utils"
 )
@@ -105,6 +108,9 @@ func addChildCommands(cmd *cobra.Command) {
 	cmd.AddCommand(
 		initCmd.NewCmdInit(),
 		device.NewCmdDevice(),
+		devicetype.NewCmdDeviceType(),
+		entity.NewCmdEntity(),
+		telemetry.NewCmdTelemetry(),
 	)
 }
 
@@ -0,0 +1,267 @@
+// Package loadtest contains the command for load testing telemetry ingestion.
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+type loadtestParams struct {
+	IDs         []string
+	devices     int
+	parameters  int
+	cfg         runConfig
+	format      string
+	output      string
+	failOnError float64
+}
+
+type loadtestCmd struct {
+	client  *aware.Client
+	params  *loadtestParams
+	devices []*aware.Device
+}
+
+// NewCmdTelemetryLoadtest is the command for load testing telemetry ingestion.
+func NewCmdTelemetryLoadtest() *cobra.Command {
+	return &cobra.Command{
+		Use:   "loadtest [ID...]",
+		Short: "Load test the telemetry ingestion endpoint",
+		Long: `Publish telemetry at a target rate and report how the ingestion endpoint coped.
+
+The rate increases from zero to --rate values per second over --ramp-up, then
+holds until --duration has passed. Values are sent for the given devices, or for
+synthetic devices that don't exist in AWARE when no IDs are given, which is only
+useful against a stand-in ingestion service.
+
+The report includes the latency percentiles of delivering each value, the error
+rate and the throughput of each response status. Delivered values are counted
+as ok whichever successful status the server responded with.`,
+		Example: `aware telemetry loadtest --rate 500 --ramp-up 30s --duration 2m
+aware telemetry loadtest 5d1d574439d157849090ea6a --rate 20 --duration 1m --format json -o report.json
+aware telemetry loadtest --devices 100 --rate 2000 --no-bulk --fail-on-error-rate 0.01`,
+		Run: loadtest,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().Float64("rate", 100, "Target values per second once ramped up")
+	cmd.Flags().Duration("ramp-up", 10*time.Second, "Time taken to increase from zero to the target rate")
+	cmd.Flags().Duration("duration", time.Minute, "Total time to generate load for, including the ramp up")
+	cmd.Flags().Int("devices", 10, "Number of synthetic devices when no IDs are given")
+	cmd.Flags().Int("parameters", 5, "Number of parameters for each synthetic device")
+	cmd.Flags().Int("batch-size", 100, "Maximum number of values sent to AWARE in one request")
+	cmd.Flags().Int("concurrency", 8, "Maximum number of requests in flight at once")
+	cmd.Flags().Bool("no-bulk", false, "Send every value in its own request")
+	cmd.Flags().String("format", formatText, "Report format, text or json")
+	cmd.Flags().StringP("output", "o", "", "File to write the report to instead of stdout")
+	cmd.Flags().Float64("fail-on-error-rate", 0, "Exit with an error if the error rate is above this, between 0 and 1")
+}
+
+func loadtest(cmd *cobra.Command, args []string) {
+	params := parseFlagsAndArgs(cmd, args)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	lc := loadtestCmd{
+		client: client,
+		params: params,
+	}
+
+	utils.ExitIfError(lc.setDevices())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+	go func() {
+		select {
+		case <-signalChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	seconds := int(params.cfg.duration / time.Second)
+	bar := utils.ShowProgress(fmt.Sprintf("Load testing at up to %g values/s", params.cfg.rate), seconds)
+
+	r := newRunner(client, params.cfg, lc.devices)
+	result := r.run(ctx, func(elapsed time.Duration) {
+		bar.Set(int(elapsed / time.Second))
+	})
+
+	bar.Set(seconds)
+	bar.Stop()
+
+	if ctx.Err() != nil {
+		utils.Warn("Load test interrupted, reporting the values sent so far")
+	}
+
+	w := io.Writer(os.Stdout)
+	if params.output != "" {
+		f, err := os.Create(params.output)
+		utils.ExitIfError(err)
+		defer func() { _ = f.Close() }()
+
+		w = f
+	}
+
+	if params.format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		utils.ExitIfError(enc.Encode(result))
+	} else {
+		utils.ExitIfError(writeText(w, result))
+	}
+
+	if params.output != "" {
+		utils.Success("Report written to %s", params.output)
+	}
+
+	if params.failOnError > 0 && result.ErrorRate > params.failOnError {
+		utils.Failed("Error rate %.2f%% is above %.2f%%", result.ErrorRate*100, params.failOnError*100)
+	}
+}
+
+func (l *loadtestCmd) setDevices() error {
+	if len(l.params.IDs) == 0 {
+		l.devices = syntheticDevices(l.params.devices, l.params.parameters)
+		return nil
+	}
+
+	s := utils.ShowLoading("Fetching Devices...")
+	defer s.Stop()
+
+	for _, id := range l.params.IDs {
+		device, err := l.client.GetDeviceByID(id)
+		if err != nil {
+			return err
+		}
+		if len(device.DeviceType.Parameters) == 0 {
+			return fmt.Errorf("device %s has no parameters to publish", id)
+		}
+		l.devices = append(l.devices, device)
+	}
+
+	return nil
+}
+
+func writeText(w io.Writer, r *report) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Duration\t%.1fs (ramp up %.1fs)\n", r.Duration, r.RampUp)
+	fmt.Fprintf(tw, "Target rate\t%.1f values/s\n", r.TargetRate)
+	fmt.Fprintf(tw, "Send rate\t%.1f values/s\n", r.SendRate)
+	fmt.Fprintf(tw, "Throughput\t%.1f values/s\n", r.Throughput)
+	fmt.Fprintf(tw, "Requests\t%d\n", r.Requests)
+	fmt.Fprintf(tw, "Values\t%d sent, %d delivered, %d failed\n", r.Sent, r.Delivered, r.Failed)
+	fmt.Fprintf(tw, "Error rate\t%.2f%%\n", r.ErrorRate*100)
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "Latency\tmin\tmean\tp50\tp90\tp95\tp99\tmax")
+	l := r.Latency
+	fmt.Fprintf(tw, "ms\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\n", l.Min, l.Mean, l.P50, l.P90, l.P95, l.P99, l.Max)
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "Status\tValues\tValues/s")
+	for _, s := range r.Statuses {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\n", s.Status, s.Count, s.Throughput)
+	}
+
+	return tw.Flush()
+}
+
+func parseFlagsAndArgs(cmd *cobra.Command, args []string) *loadtestParams {
+	rate, err := cmd.Flags().GetFloat64("rate")
+	utils.ExitIfError(err)
+
+	if rate <= 0 {
+		utils.Failed("--rate must be greater than 0")
+	}
+
+	rampUp, err := cmd.Flags().GetDuration("ramp-up")
+	utils.ExitIfError(err)
+
+	duration, err := cmd.Flags().GetDuration("duration")
+	utils.ExitIfError(err)
+
+	if rampUp < 0 || duration <= 0 || rampUp > duration {
+		utils.Failed("--duration must be greater than 0 and at least as long as --ramp-up")
+	}
+
+	devices, err := cmd.Flags().GetInt("devices")
+	utils.ExitIfError(err)
+
+	parameters, err := cmd.Flags().GetInt("parameters")
+	utils.ExitIfError(err)
+
+	if devices < 1 || parameters < 1 {
+		utils.Failed("--devices and --parameters must be at least 1")
+	}
+
+	batchSize, err := cmd.Flags().GetInt("batch-size")
+	utils.ExitIfError(err)
+
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	utils.ExitIfError(err)
+
+	noBulk, err := cmd.Flags().GetBool("no-bulk")
+	utils.ExitIfError(err)
+
+	format, err := cmd.Flags().GetString("format")
+	utils.ExitIfError(err)
+
+	format = strings.ToLower(format)
+	if format != formatText && format != formatJSON {
+		utils.Failed("--format must be text or json")
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	utils.ExitIfError(err)
+
+	failOnError, err := cmd.Flags().GetFloat64("fail-on-error-rate")
+	utils.ExitIfError(err)
+
+	return &loadtestParams{
+		IDs:        args,
+		devices:    devices,
+		parameters: parameters,
+		cfg: runConfig{
+			rate:        rate,
+			rampUp:      rampUp,
+			duration:    duration,
+			batchSize:   batchSize,
+			concurrency: concurrency,
+			disableBulk: noBulk,
+		},
+		format:      format,
+		output:      output,
+		failOnError: failOnError,
+	}
+}
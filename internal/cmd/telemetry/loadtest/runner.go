@@ -0,0 +1,312 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+)
+
+const (
+	// statusError is the status recorded for requests that failed without a response.
+	statusError = "error"
+
+	// statusDelivered is the status recorded for values that were delivered, the
+	// publisher doesn't say which successful response the server gave.
+	statusDelivered = "ok"
+)
+
+// runConfig is the load to generate.
+type runConfig struct {
+	rate        float64       // Values per second once ramped up
+	rampUp      time.Duration // Time taken to reach the rate from zero
+	duration    time.Duration // Total time including the ramp up
+	batchSize   int
+	concurrency int
+	disableBulk bool
+}
+
+// runner publishes values for the devices at the configured rate and records how
+// each was delivered.
+type runner struct {
+	client  *aware.Client
+	cfg     runConfig
+	devices []*aware.Device
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	statuses  map[string]uint64
+	sent      uint64
+}
+
+func newRunner(client *aware.Client, cfg runConfig, devices []*aware.Device) *runner {
+	return &runner{
+		client:   client,
+		cfg:      cfg,
+		devices:  devices,
+		statuses: make(map[string]uint64),
+	}
+}
+
+// target returns how many values should have been sent after elapsed,
+// increasing the rate linearly from zero over the ramp up.
+func (r *runner) target(elapsed time.Duration) float64 {
+	t := elapsed.Seconds()
+	ramp := r.cfg.rampUp.Seconds()
+
+	if ramp > 0 && t <= ramp {
+		return r.cfg.rate * t * t / (2 * ramp)
+	}
+	return r.cfg.rate*ramp/2 + r.cfg.rate*(t-ramp)
+}
+
+// run generates the load until the duration has passed or the context is done,
+// then waits for the remaining values to be delivered. tick is called periodically
+// with the time elapsed.
+func (r *runner) run(ctx context.Context, tick func(elapsed time.Duration)) *report {
+	const interval = 10 * time.Millisecond
+
+	publisher := r.client.NewPublisher(aware.PublisherConfig{
+		BatchSize:     r.cfg.batchSize,
+		Concurrency:   r.cfg.concurrency,
+		DisableBulk:   r.cfg.disableBulk,
+		FlushInterval: 100 * time.Millisecond,
+		OnDelivery:    r.onDelivery,
+	})
+
+	sources := r.sources()
+
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var sent uint64
+	func() {
+		for {
+			elapsed := time.Since(start)
+			if elapsed >= r.cfg.duration {
+				return
+			}
+
+			for target := uint64(r.target(elapsed)); sent < target; sent++ {
+				source := sources[sent%uint64(len(sources))]
+				now := time.Now()
+				v := aware.TelemetryValue{
+					DeviceID:      source.device.ID,
+					ParameterName: source.parameter.Name,
+					Value:         source.parameter.GetRandomValue(now),
+					Timestamp:     now,
+				}
+
+				if err := publisher.Publish(ctx, v); err != nil {
+					return
+				}
+			}
+
+			if tick != nil {
+				tick(elapsed)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	sendTime := time.Since(start)
+
+	r.mu.Lock()
+	r.sent = sent
+	r.mu.Unlock()
+
+	_ = publisher.Close()
+
+	return r.report(sendTime, time.Since(start), publisher.Stats().Requests)
+}
+
+// source is a parameter of a device that values are generated for.
+type source struct {
+	device    *aware.Device
+	parameter *aware.DeviceTypeParameter
+}
+
+// sources returns every parameter of every device, values are sent for each in turn.
+func (r *runner) sources() []source {
+	var out []source
+	for _, device := range r.devices {
+		for i := range device.DeviceType.Parameters {
+			out = append(out, source{device: device, parameter: &device.DeviceType.Parameters[i]})
+		}
+	}
+	return out
+}
+
+func (r *runner) onDelivery(result aware.DeliveryResult) {
+	status := statusDelivered
+	if result.Err != nil {
+		var unexpected *aware.ErrUnexpectedResponse
+		if errors.As(result.Err, &unexpected) {
+			status = strconv.Itoa(unexpected.StatusCode)
+		} else {
+			status = statusError
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.latencies = append(r.latencies, result.Latency)
+	r.statuses[status]++
+}
+
+// report is the outcome of a load test.
+type report struct {
+	TargetRate float64       `json:"targetRate"`
+	RampUp     float64       `json:"rampUpSeconds"`
+	Duration   float64       `json:"durationSeconds"`
+	Requests   uint64        `json:"requests"`
+	Sent       uint64        `json:"sent"`
+	Delivered  uint64        `json:"delivered"`
+	Failed     uint64        `json:"failed"`
+	ErrorRate  float64       `json:"errorRate"`
+	SendRate   float64       `json:"sendRate"`
+	Throughput float64       `json:"throughput"`
+	Latency    latencyReport `json:"latencyMs"`
+	Statuses   []statusCount `json:"statuses"`
+}
+
+// latencyReport is the delivery latency of values in milliseconds.
+type latencyReport struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// statusCount is the number of values delivered with a response status.
+type statusCount struct {
+	Status     string  `json:"status"`
+	Count      uint64  `json:"count"`
+	Throughput float64 `json:"throughput"`
+}
+
+func (r *runner) report(sendTime, total time.Duration, requests uint64) *report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := &report{
+		TargetRate: r.cfg.rate,
+		RampUp:     r.cfg.rampUp.Seconds(),
+		Duration:   total.Seconds(),
+		Requests:   requests,
+		Sent:       r.sent,
+		Latency:    latencies(r.latencies),
+	}
+
+	if sendTime > 0 {
+		out.SendRate = float64(r.sent) / sendTime.Seconds()
+	}
+
+	for status, count := range r.statuses {
+		if status == statusDelivered {
+			out.Delivered += count
+		} else {
+			out.Failed += count
+		}
+		out.Statuses = append(out.Statuses, statusCount{
+			Status:     status,
+			Count:      count,
+			Throughput: perSecond(count, total),
+		})
+	}
+	sort.Slice(out.Statuses, func(i, j int) bool { return out.Statuses[i].Status < out.Statuses[j].Status })
+
+	out.Throughput = perSecond(out.Delivered, total)
+	if completed := out.Delivered + out.Failed; completed > 0 {
+		out.ErrorRate = float64(out.Failed) / float64(completed)
+	}
+
+	return out
+}
+
+// latencies summarises the latencies in milliseconds, using the nearest rank for percentiles.
+func latencies(values []time.Duration) latencyReport {
+	if len(values) == 0 {
+		return latencyReport{}
+	}
+
+	sorted := append([]time.Duration(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		return milliseconds(sorted[rank])
+	}
+
+	var sum time.Duration
+	for _, v := range sorted {
+		sum += v
+	}
+
+	return latencyReport{
+		Min:  milliseconds(sorted[0]),
+		Mean: milliseconds(sum / time.Duration(len(sorted))),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		P95:  percentile(95),
+		P99:  percentile(99),
+		Max:  milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func perSecond(count uint64, d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return float64(count) / d.Seconds()
+}
+
+// syntheticDevices creates devices that don't exist in AWARE, for load testing
+// a stand-in ingestion service.
+func syntheticDevices(count, parameters int) []*aware.Device {
+	deviceType := aware.DeviceType{
+		ID:   "loadtest",
+		Name: "Load Test",
+		Kind: "loadtest",
+	}
+	for i := 0; i < parameters; i++ {
+		deviceType.Parameters = append(deviceType.Parameters, aware.DeviceTypeParameter{
+			Name:        fmt.Sprintf("value-%d", i+1),
+			DisplayName: fmt.Sprintf("Value %d", i+1),
+			ValueType:   aware.Float,
+			Range:       aware.DeviceTypeParameterRange{Min: 0, Max: 100},
+		})
+	}
+
+	devices := make([]*aware.Device, 0, count)
+	for i := 0; i < count; i++ {
+		devices = append(devices, &aware.Device{
+			ID:          fmt.Sprintf("loadtest-%04d", i+1),
+			DisplayName: fmt.Sprintf("Load Test %d", i+1),
+			DeviceType:  deviceType,
+		})
+	}
+	return devices
+}
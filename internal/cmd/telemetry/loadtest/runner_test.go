@@ -0,0 +1,133 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+func TestRunnerTarget(t *testing.T) {
+	is := is.New(t)
+
+	r := newRunner(nil, runConfig{rate: 100, rampUp: 10 * time.Second}, nil)
+
+	is.Equal(r.target(0), 0.0)
+	is.Equal(r.target(5*time.Second), 125.0)  // Half way up the ramp
+	is.Equal(r.target(10*time.Second), 500.0) // End of the ramp
+	is.Equal(r.target(12*time.Second), 700.0) // Full rate after the ramp
+
+	r.cfg.rampUp = 0
+	is.Equal(r.target(2*time.Second), 200.0)
+}
+
+func TestRunnerRun(t *testing.T) {
+	is := is.New(t)
+
+	var requests int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every fourth request fails
+		if atomic.AddInt64(&requests, 1)%4 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		time.Sleep(time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := aware.NewClient(aware.Config{Server: server.URL})
+
+	cfg := runConfig{
+		rate:        200,
+		rampUp:      200 * time.Millisecond,
+		duration:    time.Second,
+		batchSize:   1,
+		concurrency: 4,
+		disableBulk: true,
+	}
+
+	r := newRunner(client, cfg, syntheticDevices(2, 3))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result := r.run(ctx, nil)
+
+	// 20 values while ramping up and 160 at the full rate, less the last tick
+	is.True(result.Sent >= 150 && result.Sent <= 180)
+	is.Equal(result.Delivered+result.Failed, result.Sent)
+	is.Equal(result.Requests, result.Sent)
+
+	is.Equal(len(result.Statuses), 2)
+	is.Equal(result.Statuses[0].Status, "503")
+	is.Equal(result.Statuses[1].Status, "ok")
+	is.True(result.ErrorRate > 0.2 && result.ErrorRate < 0.3)
+
+	is.True(result.Latency.Min > 0)
+	is.True(result.Latency.P50 <= result.Latency.P99)
+	is.True(result.Latency.P99 <= result.Latency.Max)
+}
+
+func TestRunnerRunBulk(t *testing.T) {
+	is := is.New(t)
+
+	// Values are counted as the server receives them
+	var received int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var items []struct {
+			Values map[string]interface{} `json:"values"`
+		}
+		is.NoErr(json.NewDecoder(r.Body).Decode(&items))
+		for _, item := range items {
+			atomic.AddInt64(&received, int64(len(item.Values)))
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := aware.NewClient(aware.Config{Server: server.URL})
+
+	// Far more values each second than sources, so many are for the same parameter at once
+	cfg := runConfig{
+		rate:        500,
+		duration:    500 * time.Millisecond,
+		batchSize:   50,
+		concurrency: 2,
+	}
+
+	r := newRunner(client, cfg, syntheticDevices(1, 2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result := r.run(ctx, nil)
+
+	is.True(result.Sent > 100)
+	is.Equal(result.Failed, uint64(0))
+	is.Equal(result.Delivered, result.Sent)
+	is.Equal(uint64(atomic.LoadInt64(&received)), result.Delivered) // Nothing was lost in the bulk requests
+}
+
+func TestLatencies(t *testing.T) {
+	is := is.New(t)
+
+	var values []time.Duration
+	for i := 100; i >= 1; i-- {
+		values = append(values, time.Duration(i)*time.Millisecond)
+	}
+
+	l := latencies(values)
+	is.Equal(l.Min, 1.0)
+	is.Equal(l.P50, 50.0)
+	is.Equal(l.P90, 90.0)
+	is.Equal(l.P99, 99.0)
+	is.Equal(l.Max, 100.0)
+	is.Equal(l.Mean, 50.5)
+}
@@ -0,0 +1,246 @@
+// Package replay contains the command for replaying spooled telemetry.
+package replay
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"syscall"
+	"time"
+
+	"ampaware.com/cli/internal/spool"
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const replayingSuffix = ".replaying"
+
+type replayParams struct {
+	file      string
+	dryRun    bool
+	batchSize int
+}
+
+type replayCmd struct {
+	client  *aware.Client
+	params  *replayParams
+	files   []string
+	values  []aware.TelemetryValue
+	spooled int
+}
+
+// NewCmdTelemetryReplay is the command for replaying spooled telemetry.
+func NewCmdTelemetryReplay() *cobra.Command {
+	return &cobra.Command{
+		Use:   "replay",
+		Short: "Publish telemetry that previously failed to publish",
+		Long: `Publish telemetry that previously failed to publish.
+
+Values that fail while generating telemetry are saved to a spool file in the
+config directory. Replay publishes them in timestamp order, skipping duplicates,
+and saves any that fail again back to the spool. The spool can't be replayed while
+a generator is still saving values to it.`,
+		Example: "aware telemetry replay --dry-run",
+		Args:    cobra.NoArgs,
+		Run:     replay,
+	}
+}
+
+// SetFlags sets all the flags for the command.
+func SetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("file", "", "Spool file to replay (default is the spool in the config directory)")
+	cmd.Flags().Bool("dry-run", false, "Show what would be replayed without publishing")
+	cmd.Flags().Int("batch-size", 100, "Maximum number of values sent to AWARE in one request")
+}
+
+func replay(cmd *cobra.Command, _ []string) {
+	params := parseFlags(cmd)
+
+	client := aware.NewClient(aware.Config{
+		Server:   viper.GetString("server"),
+		Token:    viper.GetString("token"),
+		Insecure: true,
+		Debug:    viper.GetBool("debug"),
+	})
+
+	rc := replayCmd{
+		client: client,
+		params: params,
+	}
+
+	if params.dryRun {
+		utils.ExitIfError(rc.setValues(false))
+		rc.printSummary()
+		return
+	}
+
+	utils.ExitIfError(rc.setValues(true))
+
+	if len(rc.values) == 0 {
+		utils.Success("Nothing to replay")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+	go func() {
+		select {
+		case <-signalChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	delivered, err := rc.run(ctx)
+	utils.ExitIfError(err)
+
+	for _, file := range rc.files {
+		utils.ExitIfError(os.Remove(file))
+	}
+
+	if rc.spooled > 0 {
+		utils.Failed("Replayed %d of %d values, %d were saved back to the spool", delivered, len(rc.values), rc.spooled)
+	}
+
+	utils.Success("Replayed %d values", delivered)
+}
+
+// setValues reads the spool and any replays that were interrupted. When claim is set
+// the spool is moved aside first so values failing while replaying aren't lost.
+func (r *replayCmd) setValues(claim bool) error {
+	files, err := filepath.Glob(r.params.file + ".*" + replayingSuffix)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(r.params.file); err == nil {
+		file := r.params.file
+		if claim {
+			file = r.params.file + "." + strconv.FormatInt(time.Now().UnixNano(), 10) + replayingSuffix
+			if err := spool.Claim(r.params.file, file); err != nil {
+				return err
+			}
+		}
+		files = append(files, file)
+	}
+
+	var values []aware.TelemetryValue
+	for _, file := range files {
+		read, err := spool.Read(file)
+		if err != nil {
+			return err
+		}
+		values = append(values, read...)
+	}
+
+	r.files = files
+	r.values = spool.Dedupe(values)
+
+	if duplicates := len(values) - len(r.values); duplicates > 0 {
+		utils.Warn("Skipping %d duplicate values", duplicates)
+	}
+
+	return nil
+}
+
+func (r *replayCmd) printSummary() {
+	if len(r.values) == 0 {
+		fmt.Println("Nothing to replay")
+		return
+	}
+
+	perDevice := make(map[string]int)
+	for _, v := range r.values {
+		perDevice[v.DeviceID]++
+	}
+
+	devices := make([]string, 0, len(perDevice))
+	for device := range perDevice {
+		devices = append(devices, device)
+	}
+	sort.Strings(devices)
+
+	fmt.Printf("Values: %d\n", len(r.values))
+	fmt.Printf("From:   %s\n", r.values[0].Timestamp.Format(time.RFC3339))
+	fmt.Printf("To:     %s\n", r.values[len(r.values)-1].Timestamp.Format(time.RFC3339))
+	fmt.Println()
+	for _, device := range devices {
+		fmt.Printf("%s\t%d\n", device, perDevice[device])
+	}
+}
+
+// run publishes the values in order, anything that fails or isn't published
+// before being interrupted is appended back to the spool.
+func (r *replayCmd) run(ctx context.Context) (int, error) {
+	failures, err := spool.Open(r.params.file)
+	if err != nil {
+		return 0, err
+	}
+
+	bar := utils.ShowProgress("Replaying", len(r.values))
+
+	// A single request at a time keeps the values in timestamp order
+	publisher := r.client.NewPublisher(aware.PublisherConfig{
+		BatchSize:   r.params.batchSize,
+		Concurrency: 1,
+		OnDelivery: func(result aware.DeliveryResult) {
+			if result.Err != nil {
+				_ = failures.Append(result.Value)
+			}
+			bar.Increment(1)
+		},
+	})
+
+	for i, v := range r.values {
+		if err := publisher.Publish(ctx, v); err != nil {
+			for _, remaining := range r.values[i:] {
+				if err := failures.Append(remaining); err != nil {
+					return 0, err
+				}
+			}
+			break
+		}
+	}
+
+	_ = publisher.Close()
+	bar.Stop()
+
+	r.spooled = failures.Count()
+	if err := failures.Close(); err != nil {
+		return 0, err
+	}
+
+	return int(publisher.Stats().Delivered), nil
+}
+
+func parseFlags(cmd *cobra.Command) *replayParams {
+	file, err := cmd.Flags().GetString("file")
+	utils.ExitIfError(err)
+
+	if file == "" {
+		file, err = spool.DefaultPath()
+		utils.ExitIfError(err)
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	utils.ExitIfError(err)
+
+	batchSize, err := cmd.Flags().GetInt("batch-size")
+	utils.ExitIfError(err)
+
+	return &replayParams{
+		file:      file,
+		dryRun:    dryRun,
+		batchSize: batchSize,
+	}
+}
@@ -0,0 +1,37 @@
+// Package telemetry contains the root command for telemetry that isn't tied to a single device.
+package telemetry
+
+import (
+	"ampaware.com/cli/internal/cmd/telemetry/loadtest"
+	"ampaware.com/cli/internal/cmd/telemetry/replay"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdTelemetry is the root command for telemetry.
+func NewCmdTelemetry() *cobra.Command {
+	cmd := cobra.Command{
+		Use:         "telemetry",
+		Short:       "Manage Telemetry in an Organisation",
+		Long:        "Manage Telemetry across devices in an Organisation.",
+		Aliases:     []string{},
+		Annotations: map[string]string{},
+		RunE:        telemetry,
+	}
+
+	rp := replay.NewCmdTelemetryReplay()
+	lt := loadtest.NewCmdTelemetryLoadtest()
+
+	cmd.AddCommand(
+		rp,
+		lt,
+	)
+
+	replay.SetFlags(rp)
+	loadtest.SetFlags(lt)
+
+	return &cmd
+}
+
+func telemetry(cmd *cobra.Command, _ []string) error {
+	return cmd.Help()
+}
@@ -0,0 +1,23 @@
+//go:build !windows
+
+package spool
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockShared blocks until the file can be appended to alongside other writers.
+func lockShared(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_SH)
+}
+
+// tryLockExclusive locks the file if nothing else has it open for appending.
+func tryLockExclusive(f *os.File) error {
+	err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return ErrInUse
+	}
+	return err
+}
@@ -0,0 +1,14 @@
+package spool
+
+import "os"
+
+// Windows doesn't allow a file that is open elsewhere to be renamed, so claiming
+// the spool fails by itself while it is being appended to.
+
+func lockShared(*os.File) error {
+	return nil
+}
+
+func tryLockExclusive(*os.File) error {
+	return nil
+}
@@ -0,0 +1,177 @@
+// Package spool contains a local append-only store for telemetry that failed to publish.
+package spool
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"sync"
+
+	awareConfig "ampaware.com/cli/internal/config"
+	"ampaware.com/cli/pkg/aware"
+)
+
+// FileName is the name of the default spool file in the spool directory.
+const FileName = "telemetry.ndjson"
+
+// ErrInUse denotes a spool that is open for appending, such as by a running generator.
+var ErrInUse = fmt.Errorf("spool is in use, stop anything spooling telemetry and try again")
+
+// Spool appends telemetry values to a newline delimited JSON file.
+// It is safe to append from multiple goroutines.
+type Spool struct {
+	mu    sync.Mutex
+	file  *os.File
+	count int
+}
+
+// DefaultPath returns the path of the spool file in the config directory.
+func DefaultPath() (string, error) {
+	configDir, err := awareConfig.GetConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(configDir, "spool", FileName), nil
+}
+
+// Open opens the spool file for appending, creating it if required.
+func Open(file string) (*Spool, error) {
+	const (
+		dirPerm  = 0o700
+		filePerm = 0o600
+	)
+
+	if err := os.MkdirAll(path.Dir(file), dirPerm); err != nil {
+		return nil, err
+	}
+
+	// The spool may be claimed while waiting for the lock, in which case the
+	// file opened is no longer the spool and a new one is opened.
+	for {
+		f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePerm)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := lockShared(f); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+
+		opened, err := f.Stat()
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		if current, err := os.Stat(file); err == nil && os.SameFile(opened, current) {
+			return &Spool{file: f}, nil
+		}
+
+		_ = f.Close()
+	}
+}
+
+// Claim moves the spool file to the given name so it can be read without values
+// being appended to it meanwhile, failing with ErrInUse while it is open for appending.
+// Values spooled after it is claimed go to a new spool file.
+func Claim(file, to string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := tryLockExclusive(f); err != nil {
+		return err
+	}
+
+	return os.Rename(file, to)
+}
+
+// Append writes the value to the end of the spool.
+func (s *Spool) Append(v aware.TelemetryValue) error {
+	data, err := json.Marshal(&v)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	s.count++
+
+	return nil
+}
+
+// Count returns the number of values appended since the spool was opened.
+func (s *Spool) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.count
+}
+
+// Close closes the spool file.
+func (s *Spool) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// Read reads every value from the spool file.
+func Read(file string) ([]aware.TelemetryValue, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	const maxLineSize = 16 * 1024 * 1024
+
+	var out []aware.TelemetryValue
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var v aware.TelemetryValue
+		if err := json.Unmarshal(scanner.Bytes(), &v); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", file, line, err)
+		}
+		out = append(out, v)
+	}
+
+	return out, scanner.Err()
+}
+
+// Dedupe sorts the values by timestamp and removes any values for the same
+// device, parameter and timestamp, keeping the first.
+func Dedupe(values []aware.TelemetryValue) []aware.TelemetryValue {
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Timestamp.Before(values[j].Timestamp)
+	})
+
+	out := make([]aware.TelemetryValue, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		key := fmt.Sprintf("%s|%s|%d", v.DeviceID, v.ParameterName, v.Timestamp.UnixNano())
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, v)
+	}
+
+	return out
+}
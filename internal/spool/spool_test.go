@@ -0,0 +1,75 @@
+package spool
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"ampaware.com/cli/pkg/aware"
+	"github.com/matryer/is"
+)
+
+func TestSpoolRoundTrip(t *testing.T) {
+	is := is.New(t)
+
+	file := path.Join(t.TempDir(), "spool", FileName)
+
+	s, err := Open(file)
+	is.NoErr(err)
+
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	values := []aware.TelemetryValue{
+		{DeviceID: "DEV-1", ParameterName: "voltage", Value: 240.1, Timestamp: ts.Add(time.Minute)},
+		{DeviceID: "DEV-1", ParameterName: "voltage", Value: 239.8, Timestamp: ts},
+		{DeviceID: "DEV-1", ParameterName: "voltage", Value: 239.8, Timestamp: ts},
+		{DeviceID: "DEV-2", ParameterName: "voltage", Value: 241.0, Timestamp: ts},
+	}
+	for _, v := range values {
+		is.NoErr(s.Append(v))
+	}
+	is.Equal(s.Count(), 4)
+	is.NoErr(s.Close())
+
+	read, err := Read(file)
+	is.NoErr(err)
+	is.Equal(len(read), 4)
+
+	deduped := Dedupe(read)
+	is.Equal(len(deduped), 3)
+	is.True(deduped[0].Timestamp.Equal(ts))
+	is.Equal(deduped[0].DeviceID, "DEV-1")
+	is.Equal(deduped[1].DeviceID, "DEV-2")
+	is.True(deduped[2].Timestamp.Equal(ts.Add(time.Minute)))
+}
+
+func TestClaim(t *testing.T) {
+	is := is.New(t)
+
+	file := path.Join(t.TempDir(), FileName)
+	claimed := file + ".claimed"
+
+	s, err := Open(file)
+	is.NoErr(err)
+	is.NoErr(s.Append(aware.TelemetryValue{DeviceID: "DEV-1", ParameterName: "voltage", Value: 240.1}))
+
+	is.Equal(Claim(file, claimed), ErrInUse) // Still being appended to
+	is.NoErr(s.Close())
+
+	is.NoErr(Claim(file, claimed))
+
+	// Later values go to a new spool
+	s, err = Open(file)
+	is.NoErr(err)
+	is.NoErr(s.Append(aware.TelemetryValue{DeviceID: "DEV-2", ParameterName: "voltage", Value: 239.8}))
+	is.NoErr(s.Close())
+
+	read, err := Read(claimed)
+	is.NoErr(err)
+	is.Equal(len(read), 1)
+	is.Equal(read[0].DeviceID, "DEV-1")
+
+	read, err = Read(file)
+	is.NoErr(err)
+	is.Equal(len(read), 1)
+	is.Equal(read[0].DeviceID, "DEV-2")
+}
@@ -1,6 +1,12 @@
 package utils
 
-import "os"
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
 
 // StdinHasData checks if standard input has any data to be processed.
 func StdinHasData() bool {
@@ -13,3 +19,32 @@ func StdinHasData() bool {
 	}
 	return true
 }
+
+// ReadIDs reads an ID from the first field of each line, so the output of a plain
+// list with --no-headers can be piped in. Blank lines are skipped.
+func ReadIDs(r io.Reader) ([]string, error) {
+	var ids []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			ids = append(ids, fields[0])
+		}
+	}
+
+	return ids, scanner.Err()
+}
+
+// ParsePairs splits flag values given as key=value, a later value for a key replaces an earlier one.
+func ParsePairs(flag string, pairs []string) (map[string]string, error) {
+	out := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("--%s %q must be given as key=value", flag, pair)
+		}
+		out[strings.TrimSpace(key)] = value
+	}
+
+	return out, nil
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/fatih/color"
+)
+
+// ProgressBar displays the progress of a long running task in stderr.
+// It is safe to increment from multiple goroutines.
+type ProgressBar struct {
+	mu      sync.Mutex
+	bar     progress.Model
+	msg     string
+	total   int
+	current int
+}
+
+// ShowProgress displays a progress bar with the given message and total.
+func ShowProgress(msg string, total int) *ProgressBar {
+	const width = 40
+
+	p := &ProgressBar{
+		bar:   progress.New(progress.WithDefaultGradient(), progress.WithWidth(width)),
+		msg:   msg,
+		total: total,
+	}
+	p.render()
+
+	return p
+}
+
+// Set sets the current progress, used when resuming a task.
+func (p *ProgressBar) Set(current int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current = current
+	p.render()
+}
+
+// Increment moves the progress forward by n.
+func (p *ProgressBar) Increment(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current += n
+	p.render()
+}
+
+// Stop finishes the progress bar, leaving the last state on screen.
+func (p *ProgressBar) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fmt.Fprintln(color.Error)
+}
+
+func (p *ProgressBar) render() {
+	percent := 1.0
+	if p.total > 0 {
+		percent = float64(p.current) / float64(p.total)
+	}
+
+	fmt.Fprintf(color.Error, "\r%s %s %d/%d", p.msg, p.bar.ViewAs(percent), p.current, p.total)
+}
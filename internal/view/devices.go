@@ -2,8 +2,10 @@
 package view
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -169,6 +171,8 @@ func (DeviceList) assignColumns(columns []string, device *aware.Device) []string
 			bucket = append(bucket, device.ParentEntity.GetParentHierachyName())
 		case fieldEnabled:
 			bucket = append(bucket, strconv.FormatBool(device.IsEnabled))
+		case fieldActive:
+			bucket = append(bucket, strconv.FormatBool(device.IsActive))
 		}
 	}
 
@@ -183,5 +187,78 @@ func validDeviceColumns() []string {
 		fieldDescription,
 		fieldParent,
 		fieldEnabled,
+		fieldActive,
 	}
 }
+
+// DeviceDetail is a view of a single device.
+type DeviceDetail struct {
+	Device *aware.Device
+}
+
+// Render writes the details of the device with its attributes and identity.
+// Credentials are never written, only which of them are set.
+func (d *DeviceDetail) Render() error {
+	device := d.Device
+	w := tabwriter.NewWriter(os.Stdout, 0, tabWidth, 2, ' ', 0)
+
+	fmt.Fprintf(w, "ID\t%s\n", device.ID)
+	fmt.Fprintf(w, "Name\t%s\n", device.DisplayName)
+	fmt.Fprintf(w, "Type\t%s (%s)\n", device.DeviceType.Name, device.DeviceType.ID)
+	fmt.Fprintf(w, "Parent\t%s\n", device.ParentEntity.GetParentHierachyName())
+	fmt.Fprintf(w, "Organisation\t%s\n", device.Organisation)
+	fmt.Fprintf(w, "Cloud ID\t%s\n", device.CloudID)
+	fmt.Fprintf(w, "Active\t%t\n", device.IsActive)
+	fmt.Fprintf(w, "Enabled\t%t\n", device.IsEnabled)
+	fmt.Fprintf(w, "Credentials\t%s\n", credentials(device.Credentials))
+
+	fmt.Fprintf(w, "\nAttributes\t%d\n", len(device.Attributes))
+	names := make(map[string]string, len(device.DeviceType.AllowedAttributes))
+	for _, a := range device.DeviceType.AllowedAttributes {
+		names[a.Name] = a.DisplayName
+	}
+	for _, a := range device.Attributes {
+		fmt.Fprintf(w, "  %s\t%s\t%v\n", a.Name, names[a.Name], a.Value)
+	}
+
+	fmt.Fprintf(w, "\nIdentity\t%d\n", len(device.Identity))
+	for _, key := range sortedKeys(device.Identity) {
+		fmt.Fprintf(w, "  %s\t%v\n", key, device.Identity[key])
+	}
+
+	if len(device.IdentityHistory) > 0 {
+		fmt.Fprintf(w, "\nIdentity History\t%d\n", len(device.IdentityHistory))
+		for _, h := range device.IdentityHistory {
+			pairs := make([]string, 0, len(h.Identity))
+			for _, key := range sortedKeys(h.Identity) {
+				pairs = append(pairs, fmt.Sprintf("%s=%v", key, h.Identity[key]))
+			}
+			fmt.Fprintf(w, "  %s to %s\t%s\n", h.From, h.To, strings.Join(pairs, ", "))
+		}
+	}
+
+	return w.Flush()
+}
+
+// credentials describes the credentials without their values.
+func credentials(c interface{}) string {
+	switch c := c.(type) {
+	case nil:
+		return "(None)"
+	case map[string]interface{}:
+		if len(c) == 0 {
+			return "(None)"
+		}
+		return strings.Join(sortedKeys(c), ", ") + " set"
+	}
+	return "set"
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,237 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DeviceTypeDisplayFormat is a device type display type.
+type DeviceTypeDisplayFormat struct {
+	Plain     bool
+	NoHeaders bool
+}
+
+// DeviceTypeList is a list view for device types.
+type DeviceTypeList struct {
+	DeviceTypes []*aware.DeviceType
+	Display     DeviceTypeDisplayFormat
+}
+
+// Render renders the view with the given settings and options.
+func (d *DeviceTypeList) Render() error {
+	if d.Display.Plain {
+		w := tabwriter.NewWriter(os.Stdout, 0, tabWidth, 1, '\t', 0)
+		return renderPlain(w, d.data())
+	}
+
+	data := d.data()
+
+	cols := make([]table.Column, 0, len(data[0]))
+	for _, col := range data[0] {
+		cols = append(cols, table.Column{Title: col, Width: 10})
+	}
+	rows := make([]table.Row, 0, len(data)-1)
+	for _, row := range data[1:] {
+		rows = append(rows, row)
+	}
+
+	t := table.New(
+		table.WithColumns(cols),
+		table.WithRows(rows),
+		table.WithAutoWidth(true),
+		table.WithFullscreen(true),
+		table.WithCopyIndex(0),
+		table.WithHelp(),
+		table.WithFocused(true))
+
+	p := tea.NewProgram(t)
+
+	if err := p.Start(); err != nil {
+		utils.Failed("Error has occurred: %v", err)
+	}
+	return nil
+}
+
+func (d *DeviceTypeList) data() [][]string {
+	data := make([][]string, 0, len(d.DeviceTypes)+1)
+
+	if !(d.Display.Plain && d.Display.NoHeaders) {
+		data = append(data, []string{fieldUID, fieldName, fieldKind, fieldParameters, fieldScope, fieldShared, fieldActive, fieldDescription})
+	}
+
+	for _, t := range d.DeviceTypes {
+		data = append(data, []string{
+			t.ID,
+			t.Name,
+			t.Kind,
+			strconv.Itoa(len(t.Parameters)),
+			t.Scope,
+			strconv.FormatBool(t.IsShared),
+			strconv.FormatBool(t.IsActive),
+			t.Description,
+		})
+	}
+
+	return data
+}
+
+// DeviceTypeDetail is a view of a single device type and its parameters.
+type DeviceTypeDetail struct {
+	DeviceType *aware.DeviceType
+}
+
+// Render writes the details of the device type then a table of its parameters.
+func (d *DeviceTypeDetail) Render() error {
+	t := d.DeviceType
+	w := tabwriter.NewWriter(os.Stdout, 0, tabWidth, 2, ' ', 0)
+
+	fmt.Fprintf(w, "ID\t%s\n", t.ID)
+	fmt.Fprintf(w, "Name\t%s\n", t.Name)
+	fmt.Fprintf(w, "Kind\t%s\n", t.Kind)
+	fmt.Fprintf(w, "Description\t%s\n", t.Description)
+	fmt.Fprintf(w, "Scope\t%s\n", t.Scope)
+	fmt.Fprintf(w, "Organisation\t%s\n", t.Organisation)
+	fmt.Fprintf(w, "Shared\t%t\n", t.IsShared)
+	fmt.Fprintf(w, "Active\t%t\n", t.IsActive)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	sections := []func(w io.Writer){
+		d.parameters,
+		d.alarms,
+		d.attributes,
+		d.displayGroups,
+		d.commands,
+	}
+	for _, section := range sections {
+		w = tabwriter.NewWriter(os.Stdout, 0, tabWidth, 2, ' ', 0)
+		section(w)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *DeviceTypeDetail) parameters(w io.Writer) {
+	fmt.Fprintf(w, "\nParameters  %d\n", len(d.DeviceType.Parameters))
+	if len(d.DeviceType.Parameters) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "  Name\tDisplay Name\tValue Type\tUnit\tScale\tRange\tDisplay Range\tPrimary\tAggregatable\tActive")
+	for _, p := range d.DeviceType.Parameters {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%g\t%s\t%s\t%t\t%t\t%t\n",
+			p.Name,
+			p.DisplayName,
+			p.ValueType,
+			p.Display.Unit,
+			p.Display.Scale,
+			parameterRange(p.Range),
+			parameterRange(p.Display.Range),
+			p.IsPrimary,
+			p.IsAggregatable,
+			p.IsActive,
+		)
+	}
+}
+
+// alarms lists the alarms of every parameter, it is left out when there are none.
+func (d *DeviceTypeDetail) alarms(w io.Writer) {
+	count := 0
+	for _, p := range d.DeviceType.Parameters {
+		count += len(p.Alarms)
+	}
+	if count == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nAlarms  %d\n", count)
+	fmt.Fprintln(w, "  Parameter\tName\tSeverity\tCondition\tActive\tMessage")
+	for _, p := range d.DeviceType.Parameters {
+		for _, a := range p.Alarms {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s %g\t%t\t%s\n", p.Name, a.Name, a.Severity, a.Condition, a.Threshold, a.IsActive, a.Message)
+		}
+	}
+}
+
+func (d *DeviceTypeDetail) attributes(w io.Writer) {
+	if len(d.DeviceType.AllowedAttributes) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nAttributes  %d\n", len(d.DeviceType.AllowedAttributes))
+	fmt.Fprintln(w, "  Name\tDisplay Name\tValue Type\tRequired\tDefault")
+	for _, a := range d.DeviceType.AllowedAttributes {
+		def := "-"
+		if a.Default != nil {
+			def = fmt.Sprint(a.Default)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%t\t%s\n", a.Name, a.DisplayName, a.ValueType, a.IsRequired, def)
+	}
+}
+
+// displayGroups lists the groups with the parameters in them by name.
+func (d *DeviceTypeDetail) displayGroups(w io.Writer) {
+	if len(d.DeviceType.DisplayGroups) == 0 {
+		return
+	}
+
+	names := make(map[string]string, len(d.DeviceType.Parameters))
+	for _, p := range d.DeviceType.Parameters {
+		names[p.ID] = p.Name
+	}
+
+	fmt.Fprintf(w, "\nDisplay Groups  %d\n", len(d.DeviceType.DisplayGroups))
+	fmt.Fprintln(w, "  Name\tDisplay Name\tType\tOrder\tParameters")
+	for _, g := range d.DeviceType.DisplayGroups {
+		parameters := make([]string, 0, len(g.SelectedParameters))
+		for _, id := range g.SelectedParameters {
+			// Parameters missing from the type are shown by ID
+			if name, ok := names[id]; ok {
+				id = name
+			}
+			parameters = append(parameters, id)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%d\t%s\n", g.Name, g.DisplayName, g.Type, g.Order, strings.Join(parameters, ", "))
+	}
+}
+
+func (d *DeviceTypeDetail) commands(w io.Writer) {
+	if len(d.DeviceType.Commands) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nCommands  %d\n", len(d.DeviceType.Commands))
+	fmt.Fprintln(w, "  Name\tDisplay Name\tArguments\tDescription")
+	for _, c := range d.DeviceType.Commands {
+		arguments := make([]string, 0, len(c.Arguments))
+		for _, a := range c.Arguments {
+			argument := a.Name + " " + a.ValueType
+			if !a.IsRequired {
+				argument = "[" + argument + "]"
+			}
+			arguments = append(arguments, argument)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", c.Name, c.DisplayName, strings.Join(arguments, ", "), c.Description)
+	}
+}
+
+// parameterRange describes the range, which is unset when both ends are zero.
+func parameterRange(r aware.DeviceTypeParameterRange) string {
+	if r.Min == 0 && r.Max == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%g to %g", r.Min, r.Max)
+}
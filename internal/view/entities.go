@@ -0,0 +1,235 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/table"
+	"ampaware.com/cli/pkg/tui/tree"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EntityDisplayFormat is an entity display type.
+type EntityDisplayFormat struct {
+	Plain     bool
+	NoHeaders bool
+}
+
+// EntityList is a list view for entities, showing the number of devices directly under each.
+type EntityList struct {
+	Entities     []*aware.Entity
+	DeviceCounts map[string]int // By entity ID
+	Display      EntityDisplayFormat
+}
+
+// Render renders the view with the given settings and options.
+func (e *EntityList) Render() error {
+	if e.Display.Plain {
+		w := tabwriter.NewWriter(os.Stdout, 0, tabWidth, 1, '\t', 0)
+		return renderPlain(w, e.data())
+	}
+
+	data := e.data()
+
+	cols := make([]table.Column, 0, len(data[0]))
+	for _, col := range data[0] {
+		cols = append(cols, table.Column{Title: col, Width: 10})
+	}
+	rows := make([]table.Row, 0, len(data)-1)
+	for _, row := range data[1:] {
+		rows = append(rows, row)
+	}
+
+	t := table.New(
+		table.WithColumns(cols),
+		table.WithRows(rows),
+		table.WithAutoWidth(true),
+		table.WithFullscreen(true),
+		table.WithCopyIndex(0),
+		table.WithHelp(),
+		table.WithFocused(true))
+
+	p := tea.NewProgram(t)
+
+	if err := p.Start(); err != nil {
+		utils.Failed("Error has occurred: %v", err)
+	}
+	return nil
+}
+
+func (e *EntityList) data() [][]string {
+	data := make([][]string, 0, len(e.Entities)+1)
+
+	if !(e.Display.Plain && e.Display.NoHeaders) {
+		data = append(data, []string{fieldUID, fieldName, fieldParent, fieldDevices, fieldActive, fieldDescription})
+	}
+
+	for _, entity := range e.Entities {
+		parent := ""
+		if entity.ParentEntity != nil {
+			parent = entity.ParentEntity.GetParentHierachyName()
+		}
+
+		data = append(data, []string{
+			entity.ID,
+			entity.Name,
+			parent,
+			strconv.Itoa(e.DeviceCounts[entity.ID]),
+			strconv.FormatBool(entity.IsActive),
+			entity.Description,
+		})
+	}
+
+	return data
+}
+
+// EntityTree is a view of the entity hierarchy, showing the number of devices under each entity.
+type EntityTree struct {
+	Entities     []*aware.Entity
+	DeviceCounts map[string]int // By entity ID
+	Display      EntityDisplayFormat
+}
+
+// Render renders the view with the given settings and options.
+func (e *EntityTree) Render() error {
+	nodes := e.Nodes()
+
+	if e.Display.Plain {
+		_, err := io.WriteString(os.Stdout, tree.Render(nodes))
+		return err
+	}
+
+	p := tea.NewProgram(tree.New(nodes, fmt.Sprintf("%d entities", len(e.Entities))))
+	if err := p.Start(); err != nil {
+		utils.Failed("Error has occurred: %v", err)
+	}
+	return nil
+}
+
+// Nodes builds the hierarchy of the entities, entities whose parent isn't in the list
+// are at the top. Children are sorted by name.
+func (e *EntityTree) Nodes() []*tree.Node {
+	children := make(map[string][]*aware.Entity)
+	ids := make(map[string]struct{}, len(e.Entities))
+	for _, entity := range e.Entities {
+		ids[entity.ID] = struct{}{}
+	}
+
+	var roots []*aware.Entity
+	for _, entity := range e.Entities {
+		if entity.ParentEntity == nil {
+			roots = append(roots, entity)
+			continue
+		}
+		if _, ok := ids[entity.ParentEntity.ID]; !ok {
+			roots = append(roots, entity)
+			continue
+		}
+		children[entity.ParentEntity.ID] = append(children[entity.ParentEntity.ID], entity)
+	}
+
+	// Entities are only visited once, so a cycle in the hierarchy can't recurse forever
+	visited := make(map[string]struct{}, len(e.Entities))
+
+	var build func(entity *aware.Entity) (*tree.Node, int)
+	build = func(entity *aware.Entity) (*tree.Node, int) {
+		visited[entity.ID] = struct{}{}
+
+		node := &tree.Node{Label: entity.Name}
+		direct := e.DeviceCounts[entity.ID]
+		total := direct
+
+		for _, child := range sortedByName(children[entity.ID]) {
+			if _, ok := visited[child.ID]; ok {
+				continue
+			}
+			childNode, count := build(child)
+			node.Children = append(node.Children, childNode)
+			total += count
+		}
+
+		node.Detail = deviceCount(direct, total)
+		return node, total
+	}
+
+	nodes := make([]*tree.Node, 0, len(roots))
+	for _, root := range sortedByName(roots) {
+		node, _ := build(root)
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+func sortedByName(entities []*aware.Entity) []*aware.Entity {
+	sorted := append([]*aware.Entity(nil), entities...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// deviceCount describes the devices directly under an entity and in total under its children.
+func deviceCount(direct, total int) string {
+	s := fmt.Sprintf("(%d %s", direct, plural(direct, "device", "devices"))
+	if total != direct {
+		s += fmt.Sprintf(", %d in total", total)
+	}
+	return s + ")"
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// EntityDetail is a view of a single entity.
+type EntityDetail struct {
+	Entity   *aware.Entity
+	Children []*aware.Entity
+	Devices  []*aware.Device
+}
+
+// Render writes the details of the entity.
+func (e *EntityDetail) Render() error {
+	w := tabwriter.NewWriter(os.Stdout, 0, tabWidth, 2, ' ', 0)
+
+	parent := "(None)"
+	if e.Entity.ParentEntity != nil {
+		parent = e.Entity.ParentEntity.GetParentHierachyName()
+	}
+
+	fmt.Fprintf(w, "ID\t%s\n", e.Entity.ID)
+	fmt.Fprintf(w, "Name\t%s\n", e.Entity.Name)
+	fmt.Fprintf(w, "Description\t%s\n", e.Entity.Description)
+	fmt.Fprintf(w, "Parent\t%s\n", parent)
+	if e.Entity.EntityType != nil {
+		fmt.Fprintf(w, "Type\t%s\n", e.Entity.EntityType.Name)
+	}
+	fmt.Fprintf(w, "Organisation\t%s\n", e.Entity.Organisation)
+	fmt.Fprintf(w, "Active\t%t\n", e.Entity.IsActive)
+	if e.Entity.Criticality != 0 || e.Entity.ComputedCriticality != 0 {
+		fmt.Fprintf(w, "Criticality\t%d (computed %d)\n", e.Entity.Criticality, e.Entity.ComputedCriticality)
+	}
+	if e.Entity.Note != "" {
+		fmt.Fprintf(w, "Note\t%s\n", e.Entity.Note)
+	}
+
+	fmt.Fprintf(w, "\nChildren\t%d\n", len(e.Children))
+	for _, child := range sortedByName(e.Children) {
+		fmt.Fprintf(w, "  %s\t%s\n", child.ID, child.Name)
+	}
+
+	fmt.Fprintf(w, "\nDevices\t%d\n", len(e.Devices))
+	for _, device := range e.Devices {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", device.ID, device.DisplayName, device.DeviceType.Name)
+	}
+
+	return w.Flush()
+}
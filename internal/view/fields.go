@@ -8,3 +8,23 @@ const (
 	fieldParent      = "Parent"
 	fieldEnabled     = "Enabled"
 )
+
+const (
+	fieldName    = "Name"
+	fieldDevices = "Devices"
+	fieldActive  = "Active"
+)
+
+const (
+	fieldKind       = "Kind"
+	fieldParameters = "Parameters"
+	fieldScope      = "Scope"
+	fieldShared     = "Shared"
+)
+
+const (
+	fieldValueType = "Value Type"
+	fieldUnit      = "Unit"
+	fieldRange     = "Range"
+	fieldPrimary   = "Primary"
+)
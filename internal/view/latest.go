@@ -0,0 +1,261 @@
+package view
+
+import (
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/chart"
+	"ampaware.com/cli/pkg/tui/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// historySize is the number of values kept for the trend and chart of each parameter.
+	historySize = 120
+	trendWidth  = 20
+)
+
+// LatestTelemetryTable is a live view of the latest value of every parameter of the devices.
+// Each update replaces the values in the table, values that have changed since the
+// previous update are highlighted and numeric values are charted as they arrive.
+// In plain mode a line is written for every new value.
+type LatestTelemetryTable struct {
+	Devices []*aware.Device
+	Display TelemetryTableDisplayFormat
+	Updates <-chan []*aware.TelemetryValue
+	Status  func() string
+	Quit    <-chan struct{}
+
+	latest map[string]*aware.TelemetryValue
+
+	mu      sync.Mutex
+	history map[string][]float64
+}
+
+// Render renders the view with given settings and options.
+func (v *LatestTelemetryTable) Render() error {
+	v.latest = make(map[string]*aware.TelemetryValue)
+	v.history = make(map[string][]float64)
+
+	if v.Display.Plain {
+		return v.renderPlain()
+	}
+
+	initial := v.rows(nil)
+
+	snapshots := make(chan []table.Row)
+	go func() {
+		defer close(snapshots)
+		for values := range v.Updates {
+			changed := v.apply(values)
+			select {
+			case snapshots <- v.rows(changed):
+			case <-v.Quit:
+				return
+			}
+		}
+	}()
+
+	t := table.New(
+		table.WithColumns(v.getColumns()),
+		table.WithRows(initial),
+		table.WithAutoWidth(true),
+		table.WithFullscreen(true),
+		table.WithFocused(true),
+		table.WithSnapshotChannel(snapshots),
+		table.WithStatus(v.Status),
+		table.WithChartSeries(v.series),
+		table.WithCharts(chartHeight),
+	)
+
+	p := tea.NewProgram(t)
+
+	if v.Quit != nil {
+		done := make(chan struct{})
+		defer close(done)
+
+		go func() {
+			select {
+			case <-v.Quit:
+				p.Quit()
+			case <-done:
+			}
+		}()
+	}
+
+	if err := p.Start(); err != nil {
+		utils.Failed("Error has occurred: %v", err)
+	}
+
+	return nil
+}
+
+func (v *LatestTelemetryTable) renderPlain() error {
+	w := tabwriter.NewWriter(os.Stdout, 0, tabWidth, 1, '\t', 0)
+
+	if !v.Display.NoHeaders {
+		var headers []string
+		for _, col := range v.getColumns() {
+			headers = append(headers, col.Title)
+		}
+		if err := renderPlain(w, [][]string{headers}); err != nil {
+			return err
+		}
+	}
+
+	for values := range v.Updates {
+		var rows [][]string
+		for _, value := range values {
+			key := latestKey(value.DeviceID, value.ParameterName)
+			if previous, ok := v.latest[key]; ok && previous.Timestamp.Equal(value.Timestamp) {
+				continue
+			}
+			v.latest[key] = value
+
+			device, parameter := v.lookup(value.DeviceID, value.ParameterName)
+			if parameter == nil {
+				continue
+			}
+			rows = append(rows, v.row(device, parameter, value, false))
+		}
+
+		if err := renderPlain(w, rows); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// apply stores the values as the latest, returning the keys of those that changed.
+func (v *LatestTelemetryTable) apply(values []*aware.TelemetryValue) map[string]bool {
+	changed := make(map[string]bool)
+	for _, value := range values {
+		key := latestKey(value.DeviceID, value.ParameterName)
+		previous, ok := v.latest[key]
+		if ok && formatValue(previous.Value) != formatValue(value.Value) {
+			changed[key] = true
+		}
+		if f, isFloat := value.Value.(float64); isFloat && (!ok || !previous.Timestamp.Equal(value.Timestamp)) {
+			v.addHistory(key, f)
+		}
+		v.latest[key] = value
+	}
+	return changed
+}
+
+// rows returns a row for every parameter of every device, with the latest value if there is one.
+func (v *LatestTelemetryTable) rows(changed map[string]bool) []table.Row {
+	var rows []table.Row
+	for _, device := range v.Devices {
+		for i := range device.DeviceType.Parameters {
+			parameter := &device.DeviceType.Parameters[i]
+			key := latestKey(device.ID, parameter.Name)
+			row := v.row(device, parameter, v.latest[key], changed[key])
+			rows = append(rows, append(row, chart.Sparkline(v.getHistory(key), trendWidth)))
+		}
+	}
+	return rows
+}
+
+// series returns the history of the parameter shown in the row to chart.
+func (v *LatestTelemetryTable) series(row int) (chart.Series, bool) {
+	i := 0
+	for _, device := range v.Devices {
+		for _, parameter := range device.DeviceType.Parameters {
+			if i == row {
+				name := parameter.DisplayName
+				if v.isMultiDevice() {
+					name = device.DisplayName + " " + name
+				}
+				history := v.getHistory(latestKey(device.ID, parameter.Name))
+				return chart.Series{Name: name, Unit: parameter.Display.Unit, Values: history}, len(history) > 0
+			}
+			i++
+		}
+	}
+	return chart.Series{}, false
+}
+
+func (v *LatestTelemetryTable) addHistory(key string, value float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	history := append(v.history[key], value)
+	if len(history) > historySize {
+		history = history[len(history)-historySize:]
+	}
+	v.history[key] = history
+}
+
+func (v *LatestTelemetryTable) getHistory(key string) []float64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return append([]float64(nil), v.history[key]...)
+}
+
+func (v *LatestTelemetryTable) row(device *aware.Device, parameter *aware.DeviceTypeParameter, value *aware.TelemetryValue, changed bool) table.Row {
+	var row table.Row
+	if v.isMultiDevice() {
+		row = append(row, device.DisplayName)
+	}
+	row = append(row, parameter.DisplayName)
+
+	if value == nil {
+		return append(row, "", "")
+	}
+
+	formatted := formatValue(value.Value)
+	if parameter.ValueType == aware.Float && parameter.Display.Unit != "" {
+		formatted += " " + parameter.Display.Unit
+	}
+	if changed {
+		formatted = table.ChangedCellPrefix + formatted
+	}
+
+	return append(row, formatted, value.Timestamp.Local().Format(time.RFC3339))
+}
+
+func (v *LatestTelemetryTable) lookup(deviceID, parameterName string) (*aware.Device, *aware.DeviceTypeParameter) {
+	for _, device := range v.Devices {
+		if device.ID != deviceID {
+			continue
+		}
+		for i := range device.DeviceType.Parameters {
+			if device.DeviceType.Parameters[i].Name == parameterName {
+				return device, &device.DeviceType.Parameters[i]
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (v *LatestTelemetryTable) isMultiDevice() bool {
+	return len(v.Devices) > 1
+}
+
+func (v *LatestTelemetryTable) getColumns() []table.Column {
+	cols := make([]table.Column, 0)
+	if v.isMultiDevice() {
+		cols = append(cols, table.Column{Title: "Device", Width: 10})
+	}
+	cols = append(cols,
+		table.Column{Title: "Parameter", Width: 10},
+		table.Column{Title: "Value", Width: 10},
+		table.Column{Title: "Updated", Width: 10},
+	)
+	if !v.Display.Plain {
+		cols = append(cols, table.Column{Title: "Trend", Width: trendWidth})
+	}
+
+	return cols
+}
+
+func latestKey(deviceID, parameterName string) string {
+	return deviceID + "|" + parameterName
+}
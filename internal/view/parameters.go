@@ -0,0 +1,126 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/aware"
+	"ampaware.com/cli/pkg/tui/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ParameterDisplayFormat is a parameter display type.
+type ParameterDisplayFormat struct {
+	Plain     bool
+	NoHeaders bool
+}
+
+// ParameterList is a list view for the parameters of a device.
+type ParameterList struct {
+	Parameters []aware.DeviceTypeParameter
+	Display    ParameterDisplayFormat
+}
+
+// Render renders the view with the given settings and options.
+func (p *ParameterList) Render() error {
+	if p.Display.Plain {
+		w := tabwriter.NewWriter(os.Stdout, 0, tabWidth, 1, '\t', 0)
+		return renderPlain(w, p.data())
+	}
+
+	data := p.data()
+
+	cols := make([]table.Column, 0, len(data[0]))
+	for _, col := range data[0] {
+		cols = append(cols, table.Column{Title: col, Width: 10})
+	}
+	rows := make([]table.Row, 0, len(data)-1)
+	for _, row := range data[1:] {
+		rows = append(rows, row)
+	}
+
+	t := table.New(
+		table.WithColumns(cols),
+		table.WithRows(rows),
+		table.WithAutoWidth(true),
+		table.WithFullscreen(true),
+		table.WithCopyIndex(0),
+		table.WithHelp(),
+		table.WithFocused(true))
+
+	prog := tea.NewProgram(t)
+
+	if err := prog.Start(); err != nil {
+		utils.Failed("Error has occurred: %v", err)
+	}
+	return nil
+}
+
+func (p *ParameterList) data() [][]string {
+	data := make([][]string, 0, len(p.Parameters)+1)
+
+	if !(p.Display.Plain && p.Display.NoHeaders) {
+		data = append(data, []string{fieldName, fieldDisplayName, fieldValueType, fieldUnit, fieldRange, fieldPrimary, fieldActive})
+	}
+
+	for _, parameter := range p.Parameters {
+		data = append(data, []string{
+			parameter.Name,
+			parameter.DisplayName,
+			string(parameter.ValueType),
+			parameter.Display.Unit,
+			parameterRange(parameter.Range),
+			strconv.FormatBool(parameter.IsPrimary),
+			strconv.FormatBool(parameter.IsActive),
+		})
+	}
+
+	return data
+}
+
+// ParameterValue is a view of the latest value of a parameter of a device.
+type ParameterValue struct {
+	Device    *aware.Device
+	Parameter *aware.DeviceTypeParameter
+	Value     *aware.TelemetryValue
+}
+
+// Render writes the value with its unit and when it was published. Structured values are written as JSON.
+func (p *ParameterValue) Render() error {
+	w := tabwriter.NewWriter(os.Stdout, 0, tabWidth, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Device\t%s (%s)\n", p.Device.DisplayName, p.Device.ID)
+	fmt.Fprintf(w, "Parameter\t%s (%s)\n", p.Parameter.DisplayName, p.Parameter.Name)
+
+	switch p.Parameter.ValueType {
+	case aware.Object, aware.Waveform, aware.Spectrum:
+		out, err := json.MarshalIndent(p.Value.Value, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Value\t%s\n", out)
+	default:
+		value := formatValue(p.Value.Value)
+		if p.Parameter.Display.Unit != "" {
+			value += " " + p.Parameter.Display.Unit
+		}
+		fmt.Fprintf(w, "Value\t%s\n", value)
+	}
+
+	// Values shown as a label in AWARE are shown with it
+	for _, v := range p.Parameter.Display.Values {
+		if fmt.Sprint(v.Value) == fmt.Sprint(p.Value.Value) {
+			fmt.Fprintf(w, "Label\t%s\n", v.Label)
+			break
+		}
+	}
+
+	fmt.Fprintf(w, "Timestamp\t%s (%s ago)\n", p.Value.Timestamp.Local().Format(time.RFC3339), time.Since(p.Value.Timestamp).Round(time.Second))
+
+	return w.Flush()
+}
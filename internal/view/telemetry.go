@@ -1,12 +1,24 @@
 package view
 
 import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
 	"ampaware.com/cli/internal/utils"
 	"ampaware.com/cli/pkg/aware"
 	"ampaware.com/cli/pkg/tui/table"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// chartHeight is the height of the chart shown below the telemetry tables.
+const chartHeight = 12
+
+// maxTelemetryRows is the number of rows kept in the telemetry tables, older rows are
+// dropped so the table and its charts don't grow while generating for a long time.
+const maxTelemetryRows = 1000
+
 // TelemetryTableDisplayFormat is a telemetry display type.
 type TelemetryTableDisplayFormat struct {
 	Plain        bool
@@ -15,42 +27,61 @@ type TelemetryTableDisplayFormat struct {
 }
 
 // TelemetryTable is a list view for generated telemetry.
+// When there is more than one device a device column is added and the
+// parameter columns are the combination of every device's parameters.
 type TelemetryTable struct {
-	Parameters  *[]aware.DeviceTypeParameter
+	Devices     []*aware.Device
 	Display     TelemetryTableDisplayFormat
-	AppendRow   *table.Row
-	AppendReady chan byte
+	Rows        <-chan table.Row
 	InitialRows []table.Row
+	Status      func() string
+	Actions     []table.Action
+	Quit        <-chan struct{}
+}
+
+// FailedValue wraps a value that failed to publish so it is displayed as failed.
+type FailedValue struct {
+	Value interface{}
 }
 
 // Render renders the view with given settings and options.
 func (v *TelemetryTable) Render() error {
 	if v.Display.Plain {
-		return nil
+		return v.renderPlain()
 	}
 
 	cols := v.getColumns()
 
-	t := table.New(
+	opts := []table.Option{
 		table.WithColumns(cols),
 		table.WithRows(v.InitialRows),
 		table.WithAutoWidth(true),
 		table.WithFullscreen(true),
 		table.WithFocused(true),
 		table.WithStickyCursor(v.Display.StickyCursor),
-		table.WithAppending(v.AppendRow),
-	)
+		table.WithRowChannel(v.Rows),
+		table.WithMaxRows(maxTelemetryRows),
+		table.WithStatus(v.Status),
+		table.WithCharts(chartHeight),
+	}
+	if len(v.Actions) > 0 {
+		opts = append(opts, table.WithActions(v.Actions...), table.WithHelp())
+	}
 
-	p := tea.NewProgram(t)
+	p := tea.NewProgram(table.New(opts...))
 
-	go func() {
-		for {
-			<-v.AppendReady
-			p.Send(table.AppendReady)
-		}
-	}()
+	if v.Quit != nil {
+		done := make(chan struct{})
+		defer close(done)
 
-	// TODO: Fix getting stuck
+		go func() {
+			select {
+			case <-v.Quit:
+				p.Quit()
+			case <-done:
+			}
+		}()
+	}
 
 	if err := p.Start(); err != nil {
 		utils.Failed("Error has occurred: %v", err)
@@ -59,14 +90,113 @@ func (v *TelemetryTable) Render() error {
 	return nil
 }
 
-func (v *TelemetryTable) getColumns() []table.Column {
-	// var cols []table.Column
+// FormatRow formats the values published for a device into a row matching the columns.
+func (v *TelemetryTable) FormatRow(device *aware.Device, ts time.Time, values []interface{}) table.Row {
+	var row table.Row
+	row = append(row, ts.Format(time.RFC3339))
+
+	if !v.isMultiDevice() {
+		for _, val := range values {
+			row = append(row, formatValue(val))
+		}
+		return row
+	}
+
+	row = append(row, device.DisplayName)
+
+	byName := make(map[string]string, len(values))
+	for i, parameter := range device.DeviceType.Parameters {
+		if i < len(values) {
+			byName[parameter.DisplayName] = formatValue(values[i])
+		}
+	}
+	for _, name := range v.ParameterNames() {
+		row = append(row, byName[name])
+	}
+
+	return row
+}
+
+func (v *TelemetryTable) renderPlain() error {
+	w := tabwriter.NewWriter(os.Stdout, 0, tabWidth, 1, '\t', 0)
+
+	if !v.Display.NoHeaders {
+		var headers []string
+		for _, col := range v.getColumns() {
+			headers = append(headers, col.Title)
+		}
+		if err := renderPlain(w, [][]string{headers}); err != nil {
+			return err
+		}
+	}
 
+	for _, row := range v.InitialRows {
+		if err := renderPlain(w, [][]string{row}); err != nil {
+			return err
+		}
+	}
+
+	for row := range v.Rows {
+		if err := renderPlain(w, [][]string{row}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *TelemetryTable) isMultiDevice() bool {
+	return len(v.Devices) > 1
+}
+
+// ParameterNames returns the display names of every parameter across the devices
+// in the order they are first seen.
+func (v *TelemetryTable) ParameterNames() []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, device := range v.Devices {
+		for _, parameter := range device.DeviceType.Parameters {
+			if _, ok := seen[parameter.DisplayName]; ok && v.isMultiDevice() {
+				continue
+			}
+			seen[parameter.DisplayName] = struct{}{}
+			names = append(names, parameter.DisplayName)
+		}
+	}
+	return names
+}
+
+// unit returns the display unit of the first parameter with the display name.
+func (v *TelemetryTable) unit(displayName string) string {
+	for _, device := range v.Devices {
+		for _, parameter := range device.DeviceType.Parameters {
+			if parameter.DisplayName == displayName {
+				return parameter.Display.Unit
+			}
+		}
+	}
+	return ""
+}
+
+func (v *TelemetryTable) getColumns() []table.Column {
 	cols := make([]table.Column, 0)
 	cols = append(cols, table.Column{Title: "Time", Width: 10})
-	for _, val := range *v.Parameters {
-		cols = append(cols, table.Column{Title: val.DisplayName, Width: 10})
+	if v.isMultiDevice() {
+		cols = append(cols, table.Column{Title: "Device", Width: 10, Group: true})
+	}
+	for _, name := range v.ParameterNames() {
+		cols = append(cols, table.Column{Title: name, Width: 10, Unit: v.unit(name)})
 	}
 
 	return cols
 }
+
+func formatValue(val interface{}) string {
+	switch val := val.(type) {
+	case nil:
+		return ""
+	case FailedValue:
+		return table.FailedCellPrefix + formatValue(val.Value)
+	}
+	return fmt.Sprintf("%v", val)
+}
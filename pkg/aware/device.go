@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // Device is the aware model of a device when listing.
@@ -17,13 +19,26 @@ type Device struct {
 	ParentEntity Entity     `json:"parentEntity"`
 	Organisation string     `json:"organisation"`
 	CloudID      string     `json:"cloudId"`
-	// Attributes
-	// Identity
-	// IdentityHistory
-	// Credentials
 	// LatestValues
-	DisplayName string      `json:"displayName"`
-	State       interface{} `json:"state"`
+	Attributes      []DeviceAttribute      `json:"attributes,omitempty"`
+	Identity        map[string]interface{} `json:"identity,omitempty"`
+	IdentityHistory []DeviceIdentity       `json:"identityHistory,omitempty"`
+	Credentials     interface{}            `json:"credentials,omitempty"` // Shape depends on how the device connects
+	DisplayName     string                 `json:"displayName"`
+	State           interface{}            `json:"state"`
+}
+
+// DeviceAttribute is the value of one of the allowed attributes of the device's type.
+type DeviceAttribute struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// DeviceIdentity is a previous identity of a device.
+type DeviceIdentity struct {
+	Identity map[string]interface{} `json:"identity"`
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
 }
 
 // CreatedDevice is the aware model return when creating a device.
@@ -36,12 +51,12 @@ type CreatedDevice struct {
 	ParentEntity string `json:"parentEntity"`
 	Organisation string `json:"organisation"`
 	CloudID      string `json:"cloudId"`
-	// Attributes
-	// Identity
-	// IdentityHistory
-	// Credentials
 	// LatestValues
-	DisplayName string `json:"displayName"`
+	Attributes      []DeviceAttribute      `json:"attributes,omitempty"`
+	Identity        map[string]interface{} `json:"identity,omitempty"`
+	IdentityHistory []DeviceIdentity       `json:"identityHistory,omitempty"`
+	Credentials     interface{}            `json:"credentials,omitempty"`
+	DisplayName     string                 `json:"displayName"`
 }
 
 // GetAllDevicesOptions are the available options for the GetAllDevices query.
@@ -55,23 +70,113 @@ type GetAllDevicesOptions struct {
 
 // CreateDeviceRequest is the data used to create a new device.
 type CreateDeviceRequest struct {
-	DisplayName  string `json:"displayName"`
-	DeviceType   string `json:"deviceType"`
-	ParentEntity string `json:"parentEntity"`
-	Organisation string `json:"organisation"`
-	// IsActive bool
-	// IsEnabled bool
-	// Identity
-	// IdentityHistory
-	// Credentials
+	DisplayName  string                 `json:"displayName"`
+	DeviceType   string                 `json:"deviceType"`
+	ParentEntity string                 `json:"parentEntity"`
+	Organisation string                 `json:"organisation"`
+	IsActive     bool                   `json:"isActive"`
+	IsEnabled    bool                   `json:"isEnabled"`
+	Attributes   []DeviceAttribute      `json:"attributes,omitempty"`
+	Identity     map[string]interface{} `json:"identity,omitempty"`
+	Credentials  interface{}            `json:"credentials,omitempty"`
 }
 
 // UpdateDeviceRequest is the data used when updating an existing device.
 type UpdateDeviceRequest struct {
-	DeviceType   string `json:"deviceType"`
-	ParentEntity string `json:"parentEntity"`
-	Organisation string `json:"organisation"`
-	DisplayName  string `json:"displayName"`
+	DeviceType   string                 `json:"deviceType"`
+	ParentEntity string                 `json:"parentEntity"`
+	Organisation string                 `json:"organisation"`
+	DisplayName  string                 `json:"displayName"`
+	IsActive     bool                   `json:"isActive"`
+	IsEnabled    bool                   `json:"isEnabled"`
+	Attributes   []DeviceAttribute      `json:"attributes,omitempty"`
+	Identity     map[string]interface{} `json:"identity,omitempty"`
+	Credentials  interface{}            `json:"credentials,omitempty"`
+}
+
+// SetAttributes returns the attributes with the values set, which are given as text by
+// attribute name and converted to the attribute's value type. An empty value removes the
+// attribute. Only the allowed attributes of the device type can be set, any other
+// attributes are dropped, such as those kept from a device's previous type.
+func (t *DeviceType) SetAttributes(attributes []DeviceAttribute, values map[string]string) ([]DeviceAttribute, error) {
+	allowed := make(map[string]DeviceTypeAttribute, len(t.AllowedAttributes))
+	for _, a := range t.AllowedAttributes {
+		allowed[a.Name] = a
+	}
+	for name := range values {
+		if _, ok := allowed[name]; !ok {
+			return nil, fmt.Errorf("device type %s does not allow the attribute %q", t.Name, name)
+		}
+	}
+
+	out := make([]DeviceAttribute, 0, len(attributes)+len(values))
+	for _, a := range attributes {
+		if _, ok := allowed[a.Name]; !ok {
+			continue
+		}
+		if _, ok := values[a.Name]; !ok {
+			out = append(out, a)
+		}
+	}
+
+	// Set in the order of the device type so the result doesn't depend on the map
+	for _, a := range t.AllowedAttributes {
+		s, ok := values[a.Name]
+		if !ok || s == "" {
+			continue
+		}
+
+		value, err := parseAttributeValue(s, a.ValueType)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", a.Name, err)
+		}
+		out = append(out, DeviceAttribute{Name: a.Name, Value: value})
+	}
+
+	return out, nil
+}
+
+// MissingAttributes returns the names of the required attributes of the device type
+// that have no value and no default.
+func (t *DeviceType) MissingAttributes(attributes []DeviceAttribute) []string {
+	set := make(map[string]struct{}, len(attributes))
+	for _, a := range attributes {
+		set[a.Name] = struct{}{}
+	}
+
+	var missing []string
+	for _, a := range t.AllowedAttributes {
+		if _, ok := set[a.Name]; !ok && a.IsRequired && a.Default == nil {
+			missing = append(missing, a.Name)
+		}
+	}
+
+	return missing
+}
+
+func parseAttributeValue(s, valueType string) (interface{}, error) {
+	switch strings.ToLower(valueType) {
+	case "float", "number":
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a %s", s, valueType)
+		}
+		return f, nil
+	case "int", "integer":
+		i, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an %s", s, valueType)
+		}
+		return i, nil
+	case "bool", "boolean":
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a %s", s, valueType)
+		}
+		return b, nil
+	}
+
+	return s, nil
 }
 
 // CreateDevice will create a new device with the given request details.
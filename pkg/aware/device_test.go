@@ -1,6 +1,7 @@
 package aware
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -42,15 +43,15 @@ func TestGetDeviceByID(t *testing.T) {
 		IsHidden:  false,
 		CloudID:   "AMP_HST_H266_Outlet_2_IPB_5d1d574439d157849090ea6a",
 		DeviceType: DeviceType{
-			IsShared:    false,
-			IsActive:    true,
-			IsHidden:    false,
-			ID:          "5cf717e2bec882982729dd8a",
-			Name:        "IPB",
-			Kind:        "integrated-protection-relay",
-			Description: "Integrated Protection Relay",
-			Scope:       "system",
-			// "allowedAttributes": [],
+			IsShared:          false,
+			IsActive:          true,
+			IsHidden:          false,
+			ID:                "5cf717e2bec882982729dd8a",
+			Name:              "IPB",
+			Kind:              "integrated-protection-relay",
+			Description:       "Integrated Protection Relay",
+			Scope:             "system",
+			AllowedAttributes: []DeviceTypeAttribute{},
 			Parameters: []DeviceTypeParameter{
 				{
 					ID:             "5d1af1d930cbe93bdcba8239",
@@ -61,8 +62,9 @@ func TestGetDeviceByID(t *testing.T) {
 					IsPrimary:      false,
 					IsAggregatable: false,
 					Display: DeviceTypeParameterDisplay{
-						Unit:  "ohm",
-						Scale: 1,
+						Unit:   "ohm",
+						Scale:  1,
+						Values: []DeviceTypeParameterDisplayValue{},
 					},
 					Range: DeviceTypeParameterRange{
 						Min: 0,
@@ -70,35 +72,34 @@ func TestGetDeviceByID(t *testing.T) {
 					},
 				},
 			},
-			// "displayGroups": [
-			//     {
-			//         "id": "5d3142fb4ed8460012ea2628",
-			//         "name": "Settings",
-			//         "type": "list",
-			//         "displayName": "Settings",
-			//         "order": 10,
-			//         "selectedParameters": [
-			//         "5d1af1d930cbe93bdcba823b",
-			//         "5d1af1d930cbe93bdcba823c",
-			//         "5d1af1d930cbe93bdcba823d",
-			//         "5d1af1d930cbe93bdcba823e",
-			//         "5d1af1d930cbe93bdcba823f",
-			//         "5d1af1d930cbe93bdcba8240",
-			//         "5d1af1d930cbe93bdcba8251",
-			//         "5d1af1d930cbe93bdcba8252"
-			//         ]
-			//     }
-			// ]
+			DisplayGroups: []DeviceTypeDisplayGroup{
+				{
+					ID:          "5d3142fb4ed8460012ea2628",
+					Name:        "Settings",
+					Type:        "list",
+					DisplayName: "Settings",
+					Order:       10,
+					SelectedParameters: []string{
+						"5d1af1d930cbe93bdcba823b",
+						"5d1af1d930cbe93bdcba823c",
+						"5d1af1d930cbe93bdcba823d",
+						"5d1af1d930cbe93bdcba823e",
+						"5d1af1d930cbe93bdcba823f",
+						"5d1af1d930cbe93bdcba8240",
+						"5d1af1d930cbe93bdcba8251",
+						"5d1af1d930cbe93bdcba8252",
+					},
+				},
+			},
 		},
 		ParentEntity: Entity{
 			ID:          "5cf48c71b2f30979bc612292",
 			Name:        "Outlet 2",
 			Description: "DL032",
 		},
-		Organisation: "5bff4a241c7bed480ff3e261",
-		// "identityHistory": []
-		// ],
-		// "attributes": []
+		Organisation:    "5bff4a241c7bed480ff3e261",
+		IdentityHistory: []DeviceIdentity{},
+		Attributes:      []DeviceAttribute{},
 	}
 	is.Equal(expected, actual)
 
@@ -114,3 +115,70 @@ func TestGetDeviceByID(t *testing.T) {
 // Create
 // Delete
 // Get All
+
+func TestSetAttributes(t *testing.T) {
+	is := is.New(t)
+
+	data, err := os.ReadFile("./test_data/device_type.json")
+	is.NoErr(err)
+
+	var deviceType DeviceType
+	is.NoErr(json.Unmarshal(data, &deviceType))
+
+	is.Equal(deviceType.MissingAttributes(nil), []string{"serial-number"}) // Rated power has a default
+
+	attributes, err := deviceType.SetAttributes(nil, map[string]string{"rated-power": "11", "serial-number": "MC-001"})
+	is.NoErr(err)
+	is.Equal(attributes, []DeviceAttribute{{Name: "serial-number", Value: "MC-001"}, {Name: "rated-power", Value: 11.0}})
+	is.Equal(len(deviceType.MissingAttributes(attributes)), 0)
+
+	attributes, err = deviceType.SetAttributes(attributes, map[string]string{"rated-power": ""}) // Removed
+	is.NoErr(err)
+	is.Equal(attributes, []DeviceAttribute{{Name: "serial-number", Value: "MC-001"}})
+
+	_, err = deviceType.SetAttributes(attributes, map[string]string{"rated-power": "high"})
+	is.True(err != nil)
+
+	_, err = deviceType.SetAttributes(attributes, map[string]string{"colour": "red"})
+	is.True(err != nil)
+
+	// Attributes from another device type are dropped
+	attributes, err = deviceType.SetAttributes(append(attributes, DeviceAttribute{Name: "colour", Value: "red"}), nil)
+	is.NoErr(err)
+	is.Equal(attributes, []DeviceAttribute{{Name: "serial-number", Value: "MC-001"}})
+}
+
+func TestUpdateDeviceByID(t *testing.T) {
+	var unexpectedStatusCode bool
+
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(http.MethodPut, r.Method)
+		is.Equal("/v1/devices/update/TEST-1", r.URL.Path)
+
+		// A disabled device has to be sent as disabled rather than left out
+		var body map[string]interface{}
+		is.NoErr(json.NewDecoder(r.Body).Decode(&body))
+		is.Equal(body["isEnabled"], false)
+		is.Equal(body["isActive"], true)
+
+		if unexpectedStatusCode {
+			w.WriteHeader(400)
+		} else {
+			w.WriteHeader(204)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	req := &UpdateDeviceRequest{DisplayName: "Pump 1", IsActive: true, IsEnabled: false}
+	is.NoErr(client.UpdateDeviceByID("TEST-1", req))
+
+	unexpectedStatusCode = true
+	is.Equal(client.UpdateDeviceByID("TEST-1", req), &ErrUnexpectedResponse{
+		StatusCode: 400,
+		Status:     "400 Bad Request",
+	})
+}
@@ -5,27 +5,67 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-faker/faker/v4"
 )
 
 // DeviceType is the aware model for a Device Type.
 type DeviceType struct {
-	ID           string      `json:"id"`
-	Name         string      `json:"name"`
-	Kind         string      `json:"kind"`
-	Options      interface{} `json:"options"`
-	Description  string      `json:"description"`
-	IsShared     bool        `json:"isShared"`
-	IsActive     bool        `json:"isActive"`
-	IsHidden     bool        `json:"isHidden"`
-	Organisation string      `json:"organisation"`
-	Scope        string      `json:"scope"`
-	// AllowedAttributes
-	Parameters []DeviceTypeParameter `json:"parameters"`
-	// DisplayGroups
-	// Commands
+	ID                string                   `json:"id"`
+	Name              string                   `json:"name"`
+	Kind              string                   `json:"kind"`
+	Options           interface{}              `json:"options"`
+	Description       string                   `json:"description"`
+	IsShared          bool                     `json:"isShared"`
+	IsActive          bool                     `json:"isActive"`
+	IsHidden          bool                     `json:"isHidden"`
+	Organisation      string                   `json:"organisation"`
+	Scope             string                   `json:"scope"`
+	AllowedAttributes []DeviceTypeAttribute    `json:"allowedAttributes,omitempty"`
+	Parameters        []DeviceTypeParameter    `json:"parameters"`
+	DisplayGroups     []DeviceTypeDisplayGroup `json:"displayGroups,omitempty"`
+	Commands          []DeviceTypeCommand      `json:"commands,omitempty"`
+}
+
+// DeviceTypeAttribute is the aware model for an attribute devices of a type can have.
+type DeviceTypeAttribute struct {
+	Name        string      `json:"name"`
+	DisplayName string      `json:"displayName,omitempty"`
+	ValueType   string      `json:"valueType,omitempty"`
+	IsRequired  bool        `json:"isRequired,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+}
+
+// DeviceTypeDisplayGroup is the aware model for a group of parameters shown together.
+type DeviceTypeDisplayGroup struct {
+	ID                 string   `json:"id,omitempty"`
+	Name               string   `json:"name"`
+	Type               string   `json:"type"`
+	DisplayName        string   `json:"displayName"`
+	Order              int      `json:"order"`
+	SelectedParameters []string `json:"selectedParameters"` // Parameter IDs
+}
+
+// DeviceTypeCommand is the aware model for a command that can be sent to devices of a type.
+type DeviceTypeCommand struct {
+	ID          string                      `json:"id,omitempty"`
+	Name        string                      `json:"name"`
+	DisplayName string                      `json:"displayName"`
+	Description string                      `json:"description,omitempty"`
+	Arguments   []DeviceTypeCommandArgument `json:"arguments,omitempty"`
+}
+
+// DeviceTypeCommandArgument is the aware model for an argument of a command.
+type DeviceTypeCommandArgument struct {
+	Name        string      `json:"name"`
+	DisplayName string      `json:"displayName,omitempty"`
+	ValueType   string      `json:"valueType"`
+	IsRequired  bool        `json:"isRequired,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
 }
 
 // DeviceTypeParameter is the aware model for a parameter.
@@ -37,9 +77,9 @@ type DeviceTypeParameter struct {
 	IsActive       bool                         `json:"isActive"`
 	Display        DeviceTypeParameterDisplay   `json:"display"`
 	IsPrimary      bool                         `json:"isPrimary"`
-	IsAggregatable bool                         `json:"IsAggregatable"`
+	IsAggregatable bool                         `json:"isAggregatable"`
 	Range          DeviceTypeParameterRange     `json:"range"`
-	// Alarms
+	Alarms         []DeviceTypeParameterAlarm   `json:"alarms,omitempty"`
 }
 
 // DeviceTypeParameterRange is the aware model for parameter ranges.
@@ -50,11 +90,29 @@ type DeviceTypeParameterRange struct {
 
 // DeviceTypeParameterDisplay is the aware model for parameter displays.
 type DeviceTypeParameterDisplay struct {
-	Unit      string                   `json:"unit"`
-	Scale     float64                  `json:"scale"`
-	Range     DeviceTypeParameterRange `json:"range"`
-	Component string                   `json:"component"`
-	// Values
+	Unit      string                            `json:"unit"`
+	Scale     float64                           `json:"scale"`
+	Range     DeviceTypeParameterRange          `json:"range"`
+	Component string                            `json:"component"`
+	Values    []DeviceTypeParameterDisplayValue `json:"values"`
+}
+
+// DeviceTypeParameterDisplayValue is the aware model for a label shown in place of a parameter value.
+type DeviceTypeParameterDisplayValue struct {
+	Value interface{} `json:"value"`
+	Label string      `json:"label"`
+	Color string      `json:"color,omitempty"`
+}
+
+// DeviceTypeParameterAlarm is the aware model for an alarm raised by a parameter's value.
+type DeviceTypeParameterAlarm struct {
+	ID        string  `json:"id,omitempty"`
+	Name      string  `json:"name"`
+	Severity  string  `json:"severity"`
+	Condition string  `json:"condition"` // Such as above, below or equal
+	Threshold float64 `json:"threshold"`
+	Message   string  `json:"message,omitempty"`
+	IsActive  bool    `json:"isActive"`
 }
 
 // DeviceTypeParameterValueType is the aware enum for parameter value types.
@@ -133,12 +191,202 @@ func (c *Client) GetDeviceTypeByID(id string) (*DeviceType, error) {
 	return out, nil
 }
 
-// GetRandomValue generates a random value for the parameter.
+// CreateDeviceTypeRequest is the data used to create a new device type.
+type CreateDeviceTypeRequest struct {
+	Name              string                   `json:"name"`
+	Kind              string                   `json:"kind"`
+	Description       string                   `json:"description"`
+	Organisation      string                   `json:"organisation"`
+	IsShared          bool                     `json:"isShared"`
+	Options           interface{}              `json:"options,omitempty"`
+	AllowedAttributes []DeviceTypeAttribute    `json:"allowedAttributes,omitempty"`
+	Parameters        []DeviceTypeParameter    `json:"parameters"`
+	DisplayGroups     []DeviceTypeDisplayGroup `json:"displayGroups,omitempty"`
+	Commands          []DeviceTypeCommand      `json:"commands,omitempty"`
+}
+
+// UpdateDeviceTypeRequest is the data used when updating an existing device type.
+type UpdateDeviceTypeRequest struct {
+	Name              string                   `json:"name"`
+	Kind              string                   `json:"kind"`
+	Description       string                   `json:"description"`
+	Organisation      string                   `json:"organisation"`
+	IsShared          bool                     `json:"isShared"`
+	Options           interface{}              `json:"options,omitempty"`
+	AllowedAttributes []DeviceTypeAttribute    `json:"allowedAttributes,omitempty"`
+	Parameters        []DeviceTypeParameter    `json:"parameters"`
+	DisplayGroups     []DeviceTypeDisplayGroup `json:"displayGroups,omitempty"`
+	Commands          []DeviceTypeCommand      `json:"commands,omitempty"`
+}
+
+// CreateDeviceType will create a new device type with the given request details.
+func (c *Client) CreateDeviceType(req *CreateDeviceTypeRequest) (*DeviceType, error) {
+	header := Header{
+		"Accept":       "application/json",
+		"Content-Type": "application/json",
+	}
+
+	body, err := json.Marshal(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.request(context.Background(), http.MethodPost, c.server+"/v1/devicetypes", body, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, ErrEmptyResult
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, formatUnexpectedResponse(res)
+	}
+
+	var out *DeviceType
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// UpdateDeviceTypeByID updates details of a device type on aware.
+func (c *Client) UpdateDeviceTypeByID(id string, req *UpdateDeviceTypeRequest) error {
+	header := Header{
+		"Accept":       "application/json",
+		"Content-Type": "application/json",
+	}
+
+	body, err := json.Marshal(&req)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.request(context.Background(), http.MethodPut, c.server+"/v1/devicetypes/update/"+id, body, header)
+	if err != nil {
+		return err
+	}
+
+	if res == nil {
+		return ErrEmptyResult
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusNoContent {
+		return formatUnexpectedResponse(res)
+	}
+
+	return nil
+}
+
+// DeleteDeviceType will delete a device type with the given ID.
+func (c *Client) DeleteDeviceType(id string) error {
+	res, err := c.request(context.Background(), http.MethodDelete, c.server+"/v1/devicetypes/delete/"+id, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if res == nil {
+		return ErrEmptyResult
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusNoContent {
+		return formatUnexpectedResponse(res)
+	}
+
+	return nil
+}
+
+// GetParameter returns the parameter of the device type with the name, the display name
+// is matched without case when no name matches.
+func (t *DeviceType) GetParameter(name string) (*DeviceTypeParameter, error) {
+	for i := range t.Parameters {
+		if t.Parameters[i].Name == name {
+			return &t.Parameters[i], nil
+		}
+	}
+	for i := range t.Parameters {
+		if strings.EqualFold(t.Parameters[i].DisplayName, name) {
+			return &t.Parameters[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("device type %s has no parameter %q", t.Name, name)
+}
+
+// ParseValue converts the text into a value of the parameter's value type. A label
+// of the parameter's display values is accepted in place of the value it is shown for,
+// floats must be within the parameter's range when it has one.
+func (p *DeviceTypeParameter) ParseValue(s string) (interface{}, error) {
+	for _, v := range p.Display.Values {
+		if v.Label != "" && strings.EqualFold(v.Label, s) {
+			return v.Value, nil
+		}
+	}
+
+	switch p.ValueType {
+	case Float:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a float", s)
+		}
+		if (p.Range.Min != 0 || p.Range.Max != 0) && (f < p.Range.Min || f > p.Range.Max) {
+			return nil, fmt.Errorf("%g is outside the range %g to %g of %s", f, p.Range.Min, p.Range.Max, p.Name)
+		}
+		return f, nil
+	case Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a bool", s)
+		}
+		return b, nil
+	case String:
+		return s, nil
+	case Object, Waveform, Spectrum:
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, fmt.Errorf("%s value is not valid JSON", p.ValueType)
+		}
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("%s has an unknown value type %q", p.Name, p.ValueType)
+}
+
+// dateName matches names with date or datetime as a word of their own, separated by
+// anything other than a letter, such as "Commissioned Date" or "start_date_utc" but
+// not "Last Update Count".
+var dateName = regexp.MustCompile(`(^|[^a-z])date(time)?([^a-z]|$)`)
+
+// GetRandomValue generates a random value for the parameter to be published at ts,
+// which is used for the value of date parameters.
 // nolint:gocyclo // Complexity is required to generate more realistic random values
-func (p *DeviceTypeParameter) GetRandomValue() interface{} {
+func (p *DeviceTypeParameter) GetRandomValue(ts time.Time) interface{} {
+	// Structured values have to be checked first, otherwise a "Voltage Waveform"
+	// would be generated as a single voltage reading.
+	switch p.ValueType {
+	case Waveform:
+		return generateWaveform(p.Range.Max)
+	case Spectrum:
+		return generateSpectrum(p.Range.Max)
+	case Object:
+		name := strings.ToLower(p.DisplayName)
+		if strings.Contains(name, "test-report") || strings.Contains(name, "test report") {
+			return TestReportTemplate.Generate(ts)
+		}
+		return DefaultObjectTemplate.Generate(ts)
+	}
+
 	// Copied staight from Jez's LinqPad
 	// FIXME: Doesn't seem to be respecting these
-	if (p.Display != DeviceTypeParameterDisplay{} && p.Display.Unit != "") {
+	if p.Display.Unit != "" {
 		switch p.Display.Unit {
 		case "ohm", "resistance":
 			return generateRandomFloat(0, 60, 2)
@@ -174,14 +422,10 @@ func (p *DeviceTypeParameter) GetRandomValue() interface{} {
 		return generateRandomInt(0, 5)
 	case strings.Contains(name, "factor"):
 		return generateRandomFloat(0.1, 0.99, 2)
-	case strings.Contains(name, "test-report"):
-		return nil
 	case strings.Contains(name, "status"):
 		return generateRandomBool()
-	case strings.Contains(name, "date"):
-		// TODO: Now
-		// faker.Date()
-		return nil
+	case dateName.MatchString(name), dateName.MatchString(strings.ToLower(p.Name)):
+		return formatTime(ts)
 	case strings.Contains(name, "speed"):
 		return generateRandomInt(0, 10)
 	case strings.Contains(name, "frequency"):
@@ -0,0 +1,253 @@
+package aware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestGetRandomValueWaveform(t *testing.T) {
+	is := is.New(t)
+
+	p := DeviceTypeParameter{Name: "voltage-waveform", DisplayName: "Voltage Waveform", ValueType: Waveform}
+
+	value, ok := p.GetRandomValue(time.Now()).(*WaveformValue)
+	is.True(ok)
+	is.Equal(value.SampleRate, waveformFundamental*waveformSamplesPerCycle)
+	is.Equal(len(value.Samples), waveformSamplesPerCycle*waveformCycles)
+
+	for _, sample := range value.Samples {
+		is.True(sample < waveformDefaultPeak*1.2)
+		is.True(sample > -waveformDefaultPeak*1.2)
+	}
+}
+
+func TestGetRandomValueSpectrum(t *testing.T) {
+	is := is.New(t)
+
+	p := DeviceTypeParameter{Name: "current-spectrum", DisplayName: "Current Spectrum", ValueType: Spectrum}
+
+	value, ok := p.GetRandomValue(time.Now()).(*SpectrumValue)
+	is.True(ok)
+	is.Equal(len(value.Magnitudes), waveformSamplesPerCycle*waveformCycles/2)
+
+	// The fundamental should be the largest bin.
+	fundamental := int(waveformFundamental / value.Resolution)
+	for i, magnitude := range value.Magnitudes {
+		if i != fundamental {
+			is.True(magnitude < value.Magnitudes[fundamental])
+		}
+	}
+}
+
+func TestGetRandomValueObject(t *testing.T) {
+	is := is.New(t)
+
+	p := DeviceTypeParameter{Name: "last-test-report", DisplayName: "Last Test Report", ValueType: Object}
+
+	ts := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+	value, ok := p.GetRandomValue(ts).(map[string]interface{})
+	is.True(ok)
+
+	_, ok = value["passed"].(bool)
+	is.True(ok)
+
+	results, ok := value["results"].(map[string]interface{})
+	is.True(ok)
+	_, ok = results["tripTime"].(float64)
+	is.True(ok)
+
+	is.Equal(value["timestamp"], "2025-06-01T12:30:00Z")
+
+	_, err := json.Marshal(value)
+	is.NoErr(err)
+
+	p = DeviceTypeParameter{Name: "settings", DisplayName: "Settings", ValueType: Object}
+
+	value, ok = p.GetRandomValue(time.Now()).(map[string]interface{})
+	is.True(ok)
+	_, ok = value["ok"].(bool)
+	is.True(ok)
+}
+
+func TestGetRandomValueDate(t *testing.T) {
+	is := is.New(t)
+
+	// Dates are the time the value is published at, which may be in the past
+	ts := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		displayName string
+		date        bool
+	}{
+		{"commissioned-date", "Commissioned Date", true},
+		{"start_date_utc", "", true},
+		{"install-datetime", "Install Datetime", true},
+		{"last-update-count", "Last Update Count", false},
+		{"dated-reading", "Dated Reading", false},
+	}
+
+	for _, tt := range tests {
+		p := DeviceTypeParameter{Name: tt.name, DisplayName: tt.displayName, ValueType: Float}
+		is.Equal(p.GetRandomValue(ts) == "2025-06-01T12:30:00Z", tt.date) // tt.name
+	}
+}
+
+func TestCreateDeviceType(t *testing.T) {
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(http.MethodPost, r.Method)
+		is.Equal("/v1/devicetypes", r.URL.Path)
+
+		var body CreateDeviceTypeRequest
+		is.NoErr(json.NewDecoder(r.Body).Decode(&body))
+		is.Equal(body.Name, "Relay")
+		is.Equal(len(body.Parameters), 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		_, _ = w.Write([]byte(`{"id": "TYPE-1", "name": "Relay", "kind": "relay", "parameters": [{"id": "PARAM-1", "name": "voltage"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	actual, err := client.CreateDeviceType(&CreateDeviceTypeRequest{
+		Name:       "Relay",
+		Kind:       "relay",
+		Parameters: []DeviceTypeParameter{{Name: "voltage", ValueType: Float}},
+	})
+	is.NoErr(err)
+	is.Equal(actual.ID, "TYPE-1")
+	is.Equal(actual.Parameters[0].ID, "PARAM-1")
+}
+
+func TestUpdateDeviceTypeByID(t *testing.T) {
+	var unexpectedStatusCode bool
+
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(http.MethodPut, r.Method)
+		is.Equal("/v1/devicetypes/update/TYPE-1", r.URL.Path)
+
+		if unexpectedStatusCode {
+			w.WriteHeader(400)
+		} else {
+			w.WriteHeader(204)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	req := &UpdateDeviceTypeRequest{Name: "Relay", Kind: "relay"}
+	is.NoErr(client.UpdateDeviceTypeByID("TYPE-1", req))
+
+	unexpectedStatusCode = true
+	is.Equal(client.UpdateDeviceTypeByID("TYPE-1", req), &ErrUnexpectedResponse{
+		StatusCode: 400,
+		Status:     "400 Bad Request",
+	})
+}
+
+func TestDeleteDeviceType(t *testing.T) {
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(http.MethodDelete, r.Method)
+		is.Equal("/v1/devicetypes/delete/TYPE-1", r.URL.Path)
+		w.WriteHeader(204)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	is.NoErr(client.DeleteDeviceType("TYPE-1"))
+}
+
+func TestDeviceTypeRoundTrip(t *testing.T) {
+	is := is.New(t)
+
+	data, err := os.ReadFile("./test_data/device_type.json")
+	is.NoErr(err)
+
+	var deviceType DeviceType
+	is.NoErr(json.Unmarshal(data, &deviceType))
+
+	is.Equal(len(deviceType.AllowedAttributes), 2)
+	is.Equal(deviceType.AllowedAttributes[1].Default, 7.5)
+	is.Equal(deviceType.DisplayGroups[0].SelectedParameters, []string{"5d1af1d930cbe93bdcba8241", "5d1af1d930cbe93bdcba8242"})
+	is.Equal(deviceType.Commands[0].Arguments[0], DeviceTypeCommandArgument{Name: "speed", DisplayName: "Speed", ValueType: "float", IsRequired: true})
+	is.Equal(deviceType.Parameters[0].Alarms[0], DeviceTypeParameterAlarm{
+		ID:        "5d1af1d930cbe93bdcba8301",
+		Name:      "Overspeed",
+		Severity:  "critical",
+		Condition: "above",
+		Threshold: 2800,
+		Message:   "Motor is running above its rated speed",
+		IsActive:  true,
+	})
+	is.Equal(deviceType.Parameters[1].Display.Values[0], DeviceTypeParameterDisplayValue{Value: true, Label: "Running", Color: "green"})
+
+	out, err := json.Marshal(&deviceType)
+	is.NoErr(err)
+
+	var expected, actual interface{}
+	is.NoErr(json.Unmarshal(data, &expected))
+	is.NoErr(json.Unmarshal(out, &actual))
+	is.Equal(expected, actual) // Nothing is lost when written back
+}
+
+func TestParseParameterValue(t *testing.T) {
+	is := is.New(t)
+
+	data, err := os.ReadFile("./test_data/device_type.json")
+	is.NoErr(err)
+
+	var deviceType DeviceType
+	is.NoErr(json.Unmarshal(data, &deviceType))
+
+	speed, err := deviceType.GetParameter("motor-speed")
+	is.NoErr(err)
+
+	value, err := speed.ParseValue("1450.5")
+	is.NoErr(err)
+	is.Equal(value, 1450.5)
+
+	_, err = speed.ParseValue("3200") // Above the range
+	is.True(err != nil)
+
+	_, err = speed.ParseValue("fast")
+	is.True(err != nil)
+
+	state, err := deviceType.GetParameter("run state") // By display name
+	is.NoErr(err)
+	is.Equal(state.Name, "run-state")
+
+	value, err = state.ParseValue("false")
+	is.NoErr(err)
+	is.Equal(value, false)
+
+	value, err = state.ParseValue("running") // By display value label
+	is.NoErr(err)
+	is.Equal(value, true)
+
+	_, err = deviceType.GetParameter("torque")
+	is.True(err != nil)
+
+	report := DeviceTypeParameter{Name: "last-test-report", ValueType: Object}
+	value, err = report.ParseValue(`{"passed": true}`)
+	is.NoErr(err)
+	is.Equal(value, map[string]interface{}{"passed": true})
+
+	_, err = report.ParseValue("passed")
+	is.True(err != nil)
+}
@@ -9,26 +9,70 @@ import (
 
 // Entity is the aware model an entity.
 type Entity struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	Description          string                 `json:"description"`
+	Status               string                 `json:"status,omitempty"`
+	Attributes           map[string]interface{} `json:"attributes,omitempty"`
+	ParentEntity         *Entity                `json:"parentEntity"`
+	Organisation         string                 `json:"organisation"`
+	EntityType           *EntityType            `json:"entityType,omitempty"`
+	IsActive             bool                   `json:"isActive"`
+	Gateways             []string               `json:"gateways,omitempty"`
+	Files                []string               `json:"files,omitempty"`
+	Path                 string                 `json:"path,omitempty"`
+	Ancestors            []string               `json:"ancestors,omitempty"`
+	Note                 string                 `json:"note,omitempty"`
+	Order                int                    `json:"order,omitempty"`
+	Criticality          int                    `json:"criticality,omitempty"`
+	ComputedCriticality  int                    `json:"computedCriticality,omitempty"`
+	InheritedCriticality int                    `json:"inheritedCriticality,omitempty"`
+	Identity             map[string]interface{} `json:"identity,omitempty"`
+	IdentityHistory      []EntityIdentity       `json:"identityHistory,omitempty"`
+}
+
+// EntityType is the aware model of the type of an entity.
+type EntityType struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Group       string `json:"group"`
 	Description string `json:"description"`
-	// Status
-	// Attributes
-	ParentEntity *Entity `json:"parentEntity"`
-	Organisation string  `json:"organisation"`
-	// EntityType
-	IsActive bool `json:"isActive"`
-	// Gateways
-	// Files
-	// Path
-	// Ancestors
-	// Note
-	// Order
-	// Criticality
-	// ComputedCriticality
-	// InheritedCriticality
-	// Identity
-	// IdentityHistory
+}
+
+// EntityIdentity is a previous identity of an entity.
+type EntityIdentity struct {
+	Identity map[string]interface{} `json:"identity"`
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
+}
+
+// CreatedEntity is the aware model returned when creating an entity.
+type CreatedEntity struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ParentEntity string `json:"parentEntity"`
+	Organisation string `json:"organisation"`
+	IsActive     bool   `json:"isActive"`
+}
+
+// CreateEntityRequest is the data used to create a new entity.
+type CreateEntityRequest struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ParentEntity string `json:"parentEntity,omitempty"`
+	Organisation string `json:"organisation"`
+}
+
+// UpdateEntityRequest is the data used when updating an existing entity.
+// The update replaces the parent, so an empty ParentEntity moves the entity to the
+// top of the hierarchy.
+type UpdateEntityRequest struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ParentEntity string `json:"parentEntity"`
+	Organisation string `json:"organisation"`
 }
 
 // GetAllEntitiesOptions are the available options for the GetAllEntities query.
@@ -43,8 +87,25 @@ type GetAllEntitiesOptions struct {
 // GetAllEntities attempts to retrieve all entities for an organistion.
 // org is required.
 func (c *Client) GetAllEntities(org string, opts GetAllEntitiesOptions) ([]*Entity, error) {
-	// TODO: Opts
-	url := fmt.Sprintf("%s/v1/entities?organisationId=%s", c.server, org)
+	queryString := fmt.Sprintf("organisationId=%s", org)
+
+	if opts.IncludeInactive {
+		queryString += fmt.Sprintf("&includeInactive=%v", opts.IncludeInactive)
+	}
+	if opts.ExcludeDetail {
+		queryString += fmt.Sprintf("&excludeDetail=%v", opts.ExcludeDetail)
+	}
+	if opts.ParentEntityID != "" {
+		queryString += fmt.Sprintf("&parentEntityId=%s", opts.ParentEntityID)
+	}
+	if opts.Group != "" {
+		queryString += fmt.Sprintf("&group=%s", opts.Group)
+	}
+	if opts.Kind != "" {
+		queryString += fmt.Sprintf("&kind=%s", opts.Kind)
+	}
+
+	url := c.server + "/v1/entities?" + queryString
 
 	res, err := c.request(context.Background(), http.MethodGet, url, nil, nil)
 	if err != nil {
@@ -69,6 +130,118 @@ func (c *Client) GetAllEntities(org string, opts GetAllEntitiesOptions) ([]*Enti
 	return out, nil
 }
 
+// GetEntityByID attempts to retrieve an entity with the given id.
+func (c *Client) GetEntityByID(id string) (*Entity, error) {
+	url := c.server + "/v1/entities/" + id
+
+	res, err := c.request(context.Background(), http.MethodGet, url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, ErrEmptyResult
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, formatUnexpectedResponse(res)
+	}
+
+	var out *Entity
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// CreateEntity will create a new entity with the given request details.
+func (c *Client) CreateEntity(req *CreateEntityRequest) (*CreatedEntity, error) {
+	header := Header{
+		"Accept":       "application/json",
+		"Content-Type": "application/json",
+	}
+
+	body, err := json.Marshal(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.request(context.Background(), http.MethodPost, c.server+"/v1/entities", body, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, ErrEmptyResult
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, formatUnexpectedResponse(res)
+	}
+
+	var out *CreatedEntity
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// UpdateEntityByID updates details of an entity on aware.
+func (c *Client) UpdateEntityByID(id string, req *UpdateEntityRequest) error {
+	header := Header{
+		"Accept":       "application/json",
+		"Content-Type": "application/json",
+	}
+
+	body, err := json.Marshal(&req)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.request(context.Background(), http.MethodPut, c.server+"/v1/entities/update/"+id, body, header)
+	if err != nil {
+		return err
+	}
+
+	if res == nil {
+		return ErrEmptyResult
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusNoContent {
+		return formatUnexpectedResponse(res)
+	}
+
+	return nil
+}
+
+// DeleteEntity will delete an entity with the given ID.
+func (c *Client) DeleteEntity(id string) error {
+	res, err := c.request(context.Background(), http.MethodDelete, c.server+"/v1/entities/delete/"+id, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if res == nil {
+		return ErrEmptyResult
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusNoContent {
+		return formatUnexpectedResponse(res)
+	}
+
+	return nil
+}
+
 // GetParentHierachyName returns a string of the full entity hierachy path.
 // i.e. AGL Conveyor Motor Sensor instead of just Sensor.
 func (e *Entity) GetParentHierachyName() string {
@@ -77,3 +250,13 @@ func (e *Entity) GetParentHierachyName() string {
 	}
 	return e.ParentEntity.GetParentHierachyName() + " -> " + e.Name
 }
+
+// IsUnder returns whether the entity or any of its parents has the given ID.
+func (e *Entity) IsUnder(id string) bool {
+	for entity := e; entity != nil; entity = entity.ParentEntity {
+		if entity.ID == id {
+			return true
+		}
+	}
+	return false
+}
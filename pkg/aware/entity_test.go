@@ -0,0 +1,239 @@
+package aware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGetEntityByID(t *testing.T) {
+	var unexpectedStatusCode bool
+
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(http.MethodGet, r.Method)
+		is.Equal("/v1/entities/TEST-1", r.URL.Path)
+
+		if unexpectedStatusCode {
+			w.WriteHeader(400)
+		} else {
+			resp, err := os.ReadFile("./test_data/entity.json")
+			is.NoErr(err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(200)
+			_, _ = w.Write(resp)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	actual, err := client.GetEntityByID("TEST-1")
+	is.NoErr(err)
+
+	expected := &Entity{
+		ID:          "5cf48c71b2f30979bc612292",
+		Name:        "Outlet 2",
+		Description: "DL032",
+		Status:      "running",
+		Attributes:  map[string]interface{}{"voltage": "1000V"},
+		ParentEntity: &Entity{
+			ID:   "5cf48c71b2f30979bc612291",
+			Name: "Substation 1",
+		},
+		Organisation: "5cf48c71b2f30979bc612290",
+		EntityType: &EntityType{
+			ID:          "5cf48c71b2f30979bc612280",
+			Name:        "Outlet",
+			Kind:        "outlet",
+			Group:       "electrical",
+			Description: "Substation Outlet",
+		},
+		IsActive:             true,
+		Gateways:             []string{},
+		Files:                []string{},
+		Path:                 "/5cf48c71b2f30979bc612291/5cf48c71b2f30979bc612292",
+		Ancestors:            []string{"5cf48c71b2f30979bc612291"},
+		Note:                 "Feeds conveyor 3",
+		Order:                2,
+		Criticality:          3,
+		ComputedCriticality:  4,
+		InheritedCriticality: 4,
+		Identity:             map[string]interface{}{"serial": "DL032"},
+		IdentityHistory: []EntityIdentity{
+			{
+				Identity: map[string]interface{}{"serial": "DL031"},
+				From:     "2019-06-01T00:00:00.000Z",
+				To:       "2020-01-01T00:00:00.000Z",
+			},
+		},
+	}
+	is.Equal(expected, actual)
+	is.Equal(actual.GetParentHierachyName(), "Substation 1 -> Outlet 2")
+
+	unexpectedStatusCode = true
+	_, err = client.GetEntityByID("TEST-1")
+	is.Equal(err, &ErrUnexpectedResponse{
+		StatusCode: 400,
+		Status:     "400 Bad Request",
+	})
+}
+
+func TestGetAllEntities(t *testing.T) {
+	var query string
+
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(http.MethodGet, r.Method)
+		is.Equal("/v1/entities", r.URL.Path)
+		query = r.URL.RawQuery
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte(`[{"id": "ENT-1", "name": "Site"}, {"id": "ENT-2", "name": "Line", "parentEntity": {"id": "ENT-1", "name": "Site"}}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	entities, err := client.GetAllEntities("ORG-1", GetAllEntitiesOptions{})
+	is.NoErr(err)
+	is.Equal(query, "organisationId=ORG-1")
+	is.Equal(len(entities), 2)
+	is.Equal(entities[1].GetParentHierachyName(), "Site -> Line")
+
+	_, err = client.GetAllEntities("ORG-1", GetAllEntitiesOptions{
+		IncludeInactive: true,
+		ExcludeDetail:   true,
+		ParentEntityID:  "ENT-1",
+		Group:           "electrical",
+		Kind:            "outlet",
+	})
+	is.NoErr(err)
+	is.Equal(query, "organisationId=ORG-1&includeInactive=true&excludeDetail=true&parentEntityId=ENT-1&group=electrical&kind=outlet")
+}
+
+func TestCreateEntity(t *testing.T) {
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(http.MethodPost, r.Method)
+		is.Equal("/v1/entities", r.URL.Path)
+
+		var body map[string]interface{}
+		is.NoErr(json.NewDecoder(r.Body).Decode(&body))
+		is.Equal(body, map[string]interface{}{
+			"name":         "Line",
+			"description":  "Conveyor line",
+			"organisation": "ORG-1",
+		}) // No parent is omitted
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		_, _ = w.Write([]byte(`{"id": "ENT-2", "name": "Line", "description": "Conveyor line", "organisation": "ORG-1", "isActive": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	actual, err := client.CreateEntity(&CreateEntityRequest{
+		Name:         "Line",
+		Description:  "Conveyor line",
+		Organisation: "ORG-1",
+	})
+	is.NoErr(err)
+	is.Equal(actual, &CreatedEntity{
+		ID:           "ENT-2",
+		Name:         "Line",
+		Description:  "Conveyor line",
+		Organisation: "ORG-1",
+		IsActive:     true,
+	})
+}
+
+func TestUpdateEntityByID(t *testing.T) {
+	var unexpectedStatusCode bool
+	parent := "ENT-1"
+
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(http.MethodPut, r.Method)
+		is.Equal("/v1/entities/update/ENT-2", r.URL.Path)
+
+		// The parent is sent even when empty so an entity can be moved to the top
+		var body map[string]interface{}
+		is.NoErr(json.NewDecoder(r.Body).Decode(&body))
+		is.Equal(body["parentEntity"], parent)
+
+		if unexpectedStatusCode {
+			w.WriteHeader(404)
+		} else {
+			w.WriteHeader(204)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	req := &UpdateEntityRequest{Name: "Line", ParentEntity: "ENT-1", Organisation: "ORG-1"}
+	is.NoErr(client.UpdateEntityByID("ENT-2", req))
+
+	parent = ""
+	req.ParentEntity = ""
+	is.NoErr(client.UpdateEntityByID("ENT-2", req))
+
+	unexpectedStatusCode = true
+	is.Equal(client.UpdateEntityByID("ENT-2", req), &ErrUnexpectedResponse{
+		StatusCode: 404,
+		Status:     "404 Not Found",
+	})
+}
+
+func TestDeleteEntity(t *testing.T) {
+	var unexpectedStatusCode bool
+
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(http.MethodDelete, r.Method)
+		is.Equal("/v1/entities/delete/ENT-2", r.URL.Path)
+
+		if unexpectedStatusCode {
+			w.WriteHeader(409)
+		} else {
+			w.WriteHeader(204)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	is.NoErr(client.DeleteEntity("ENT-2"))
+
+	unexpectedStatusCode = true
+	is.Equal(client.DeleteEntity("ENT-2"), &ErrUnexpectedResponse{
+		StatusCode: 409,
+		Status:     "409 Conflict",
+	})
+}
+
+func TestEntityIsUnder(t *testing.T) {
+	is := is.New(t)
+
+	site := &Entity{ID: "SITE", Name: "Site"}
+	line := &Entity{ID: "LINE", Name: "Line 1", ParentEntity: site}
+	outlet := &Entity{ID: "OUTLET", Name: "Outlet 2", ParentEntity: line}
+
+	is.True(outlet.IsUnder("OUTLET"))
+	is.True(outlet.IsUnder("SITE"))
+	is.True(!line.IsUnder("OUTLET"))
+	is.True(!outlet.IsUnder("OTHER"))
+}
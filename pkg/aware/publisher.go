@@ -0,0 +1,363 @@
+package aware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrPublisherClosed denotes a value being published after the publisher was closed.
+var ErrPublisherClosed = fmt.Errorf("aware: publisher is closed")
+
+// TelemetryValue is a single parameter value for a device at a point in time.
+type TelemetryValue struct {
+	DeviceID      string      `json:"device"`
+	ParameterName string      `json:"parameter"`
+	Value         interface{} `json:"value"`
+	Timestamp     time.Time   `json:"timestamp"`
+}
+
+// DeliveryResult is the outcome of publishing a single value.
+type DeliveryResult struct {
+	Value   TelemetryValue
+	Err     error
+	Latency time.Duration
+}
+
+// PublisherConfig configures how a Publisher batches and delivers values.
+type PublisherConfig struct {
+	// BatchSize is the maximum number of values sent in one request.
+	BatchSize int
+	// FlushInterval is the longest a value will wait before being sent.
+	FlushInterval time.Duration
+	// BufferSize is how many values can be queued before Publish blocks.
+	BufferSize int
+	// Concurrency is the maximum number of requests in flight at once.
+	Concurrency int
+	// DisableBulk always publishes values individually.
+	DisableBulk bool
+	// OnDelivery is called from the publisher's goroutines after every value
+	// has been delivered or has failed.
+	OnDelivery func(DeliveryResult)
+}
+
+// PublisherStats are the delivery statistics of a Publisher.
+type PublisherStats struct {
+	Queued        uint64
+	Delivered     uint64
+	Failed        uint64
+	Requests      uint64
+	BulkSupported bool
+}
+
+// Publisher batches telemetry values and delivers them to the ingestion endpoint.
+// Values for the same device and timestamp are sent together in a bulk request,
+// if the server doesn't support bulk ingestion the values are sent individually
+// with at most Concurrency requests at once.
+type Publisher struct {
+	client *Client
+	cfg    PublisherConfig
+
+	in      chan TelemetryValue
+	flush   chan struct{}
+	batches chan []TelemetryValue
+	done    chan struct{}
+	workers sync.WaitGroup
+
+	closeOnce sync.Once
+	closeLock sync.RWMutex
+	closed    bool
+
+	pendingLock sync.Mutex
+	pending     int
+	idle        []chan struct{}
+
+	queued    uint64
+	delivered uint64
+	failed    uint64
+	requests  uint64
+	noBulk    int32
+}
+
+type telemetryBulkItem struct {
+	DeviceID  string                 `json:"device"`
+	Timestamp string                 `json:"timestamp"`
+	Values    map[string]interface{} `json:"values"`
+}
+
+// NewPublisher creates a publisher and starts its delivery goroutines.
+// Close must be called to deliver any remaining values.
+func (c *Client) NewPublisher(cfg PublisherConfig) *Publisher {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 1000
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+
+	p := &Publisher{
+		client:  c,
+		cfg:     cfg,
+		in:      make(chan TelemetryValue, cfg.BufferSize),
+		flush:   make(chan struct{}, 1),
+		batches: make(chan []TelemetryValue),
+		done:    make(chan struct{}),
+	}
+
+	if cfg.DisableBulk {
+		p.noBulk = 1
+	}
+
+	for i := 0; i < cfg.Concurrency; i++ {
+		p.workers.Add(1)
+		go p.sender()
+	}
+
+	go p.batcher()
+
+	return p
+}
+
+// Publish queues a value to be delivered, blocking while the queue is full.
+func (p *Publisher) Publish(ctx context.Context, v TelemetryValue) error {
+	p.closeLock.RLock()
+	defer p.closeLock.RUnlock()
+
+	if p.closed {
+		return ErrPublisherClosed
+	}
+
+	p.addPending(1)
+
+	select {
+	case p.in <- v:
+		atomic.AddUint64(&p.queued, 1)
+		return nil
+	case <-ctx.Done():
+		p.addPending(-1)
+		return ctx.Err()
+	}
+}
+
+// Flush sends any batched values and waits for every queued value to be delivered.
+func (p *Publisher) Flush(ctx context.Context) error {
+	p.pendingLock.Lock()
+	if p.pending == 0 {
+		p.pendingLock.Unlock()
+		return nil
+	}
+	idle := make(chan struct{})
+	p.idle = append(p.idle, idle)
+	p.pendingLock.Unlock()
+
+	select {
+	case p.flush <- struct{}{}:
+	default:
+	}
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close delivers any queued values and stops the publisher.
+func (p *Publisher) Close() error {
+	p.closeOnce.Do(func() {
+		p.closeLock.Lock()
+		p.closed = true
+		close(p.in)
+		p.closeLock.Unlock()
+
+		<-p.done
+		p.workers.Wait()
+	})
+
+	return nil
+}
+
+// Stats returns the current delivery statistics.
+func (p *Publisher) Stats() PublisherStats {
+	return PublisherStats{
+		Queued:        atomic.LoadUint64(&p.queued),
+		Delivered:     atomic.LoadUint64(&p.delivered),
+		Failed:        atomic.LoadUint64(&p.failed),
+		Requests:      atomic.LoadUint64(&p.requests),
+		BulkSupported: atomic.LoadInt32(&p.noBulk) == 0,
+	}
+}
+
+func (p *Publisher) addPending(n int) {
+	p.pendingLock.Lock()
+	defer p.pendingLock.Unlock()
+
+	p.pending += n
+	if p.pending == 0 {
+		for _, idle := range p.idle {
+			close(idle)
+		}
+		p.idle = nil
+	}
+}
+
+// batcher collects values from the queue until the batch is full, the flush
+// interval passes or a flush is requested.
+func (p *Publisher) batcher() {
+	defer close(p.done)
+	defer close(p.batches)
+
+	ticker := time.NewTicker(p.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]TelemetryValue, 0, p.cfg.BatchSize)
+	send := func() {
+		if len(batch) == 0 {
+			return
+		}
+		p.batches <- batch
+		batch = make([]TelemetryValue, 0, p.cfg.BatchSize)
+	}
+
+	for {
+		select {
+		case v, ok := <-p.in:
+			if !ok {
+				send()
+				return
+			}
+			batch = append(batch, v)
+			if len(batch) >= p.cfg.BatchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case <-p.flush:
+			// Take everything already queued before sending
+			for len(p.in) > 0 {
+				v, ok := <-p.in
+				if !ok {
+					break
+				}
+				batch = append(batch, v)
+				if len(batch) >= p.cfg.BatchSize {
+					send()
+				}
+			}
+			send()
+		}
+	}
+}
+
+func (p *Publisher) sender() {
+	defer p.workers.Done()
+
+	for batch := range p.batches {
+		if atomic.LoadInt32(&p.noBulk) == 0 {
+			start := time.Now()
+			err := p.client.publishTelemetryBulk(batch)
+			atomic.AddUint64(&p.requests, 1)
+
+			if !isBulkUnsupported(err) {
+				p.report(batch, err, time.Since(start))
+				continue
+			}
+
+			atomic.StoreInt32(&p.noBulk, 1)
+		}
+
+		for _, v := range batch {
+			start := time.Now()
+			err := p.client.PublishTelemetry(v.DeviceID, v.ParameterName, v.Value, v.Timestamp)
+			atomic.AddUint64(&p.requests, 1)
+			p.report([]TelemetryValue{v}, err, time.Since(start))
+		}
+	}
+}
+
+func (p *Publisher) report(values []TelemetryValue, err error, latency time.Duration) {
+	for _, v := range values {
+		if err != nil {
+			atomic.AddUint64(&p.failed, 1)
+		} else {
+			atomic.AddUint64(&p.delivered, 1)
+		}
+
+		if p.cfg.OnDelivery != nil {
+			p.cfg.OnDelivery(DeliveryResult{Value: v, Err: err, Latency: latency})
+		}
+	}
+
+	p.addPending(-len(values))
+}
+
+func isBulkUnsupported(err error) bool {
+	if e, ok := err.(*ErrUnexpectedResponse); ok {
+		switch e.StatusCode {
+		case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return true
+		}
+	}
+	return false
+}
+
+// publishTelemetryBulk publishes many values in one request, grouped by device and timestamp.
+// A second value for a parameter at the same time starts a new group so neither is lost.
+func (c *Client) publishTelemetryBulk(values []TelemetryValue) error {
+	items := make([]*telemetryBulkItem, 0)
+	index := make(map[string]*telemetryBulkItem)
+	for _, v := range values {
+		key := v.DeviceID + "|" + strconv.FormatInt(v.Timestamp.UnixNano(), 10)
+		item, ok := index[key]
+		if ok {
+			_, taken := item.Values[v.ParameterName]
+			ok = !taken
+		}
+		if !ok {
+			item = &telemetryBulkItem{DeviceID: v.DeviceID, Timestamp: v.Timestamp.Format(time.RFC3339Nano), Values: make(map[string]interface{})}
+			index[key] = item
+			items = append(items, item)
+		}
+		item.Values[v.ParameterName] = v.Value
+	}
+
+	header := Header{
+		"Accept":       "application/json",
+		"Content-Type": "application/json",
+	}
+
+	body, err := json.Marshal(&items)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.request(context.Background(), http.MethodPost, c.server+"/v1/ingestion/ingest/bulk", body, header)
+	if err != nil {
+		return err
+	}
+
+	if res == nil {
+		return ErrEmptyResult
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent:
+		return nil
+	}
+
+	return formatUnexpectedResponse(res)
+}
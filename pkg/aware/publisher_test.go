@@ -0,0 +1,225 @@
+package aware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPublisherBulk(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []telemetryBulkItem
+	)
+
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal("/v1/ingestion/ingest/bulk", r.URL.Path)
+
+		var items []telemetryBulkItem
+		is.NoErr(json.NewDecoder(r.Body).Decode(&items))
+
+		mu.Lock()
+		received = append(received, items...)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+	publisher := client.NewPublisher(PublisherConfig{FlushInterval: time.Hour})
+
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, device := range []string{"DEV-1", "DEV-2"} {
+		for _, parameter := range []string{"voltage", "current", "power"} {
+			is.NoErr(publisher.Publish(context.Background(), TelemetryValue{
+				DeviceID:      device,
+				ParameterName: parameter,
+				Value:         1.5,
+				Timestamp:     ts,
+			}))
+		}
+	}
+
+	is.NoErr(publisher.Flush(context.Background()))
+	is.NoErr(publisher.Close())
+
+	// One request with a group for each device
+	is.Equal(len(received), 2)
+	is.Equal(received[0].DeviceID, "DEV-1")
+	is.Equal(received[0].Timestamp, "2026-01-01T00:00:00Z")
+	is.Equal(len(received[0].Values), 3)
+
+	stats := publisher.Stats()
+	is.Equal(stats.Queued, uint64(6))
+	is.Equal(stats.Delivered, uint64(6))
+	is.Equal(stats.Failed, uint64(0))
+	is.Equal(stats.Requests, uint64(1))
+	is.True(stats.BulkSupported)
+}
+
+func TestPublisherBulkSameTime(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []telemetryBulkItem
+	)
+
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var items []telemetryBulkItem
+		is.NoErr(json.NewDecoder(r.Body).Decode(&items))
+
+		mu.Lock()
+		received = append(received, items...)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+	publisher := client.NewPublisher(PublisherConfig{FlushInterval: time.Hour})
+
+	// Two values in the same second, and two at exactly the same time
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{ts, ts.Add(250 * time.Millisecond), ts.Add(250 * time.Millisecond)} {
+		is.NoErr(publisher.Publish(context.Background(), TelemetryValue{
+			DeviceID:      "DEV-1",
+			ParameterName: "voltage",
+			Value:         1.5,
+			Timestamp:     at,
+		}))
+	}
+
+	is.NoErr(publisher.Flush(context.Background()))
+	is.NoErr(publisher.Close())
+
+	values := 0
+	for _, item := range received {
+		values += len(item.Values)
+	}
+	is.Equal(values, 3) // Every value reached the server
+	is.Equal(len(received), 3)
+	is.Equal(received[0].Timestamp, "2026-01-01T00:00:00Z")
+	is.Equal(received[1].Timestamp, "2026-01-01T00:00:00.25Z")
+
+	stats := publisher.Stats()
+	is.Equal(stats.Delivered, uint64(values))
+}
+
+func TestPublisherFallback(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		timestamps []string
+	)
+
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/ingestion/ingest/bulk":
+			w.WriteHeader(http.StatusNotFound)
+		case "/v1/ingestion/ingest":
+			var body struct {
+				Timestamp string `json:"timestamp"`
+			}
+			is.NoErr(json.NewDecoder(r.Body).Decode(&body))
+
+			mu.Lock()
+			timestamps = append(timestamps, body.Timestamp)
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+	publisher := client.NewPublisher(PublisherConfig{BatchSize: 2, Concurrency: 2})
+
+	// Values within the same second keep their own timestamps, as they do in bulk requests
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		is.NoErr(publisher.Publish(context.Background(), TelemetryValue{
+			DeviceID:      "DEV-1",
+			ParameterName: "voltage",
+			Value:         i,
+			Timestamp:     ts.Add(time.Duration(i) * 100 * time.Millisecond),
+		}))
+	}
+
+	is.NoErr(publisher.Close())
+
+	sort.Strings(timestamps)
+	is.Equal(timestamps, []string{
+		"2026-01-01T00:00:00.1Z",
+		"2026-01-01T00:00:00.2Z",
+		"2026-01-01T00:00:00.3Z",
+		"2026-01-01T00:00:00.4Z",
+		"2026-01-01T00:00:00Z",
+	})
+
+	stats := publisher.Stats()
+	is.Equal(stats.Delivered, uint64(5))
+	is.True(!stats.BulkSupported)
+
+	is.Equal(publisher.Publish(context.Background(), TelemetryValue{}), ErrPublisherClosed)
+}
+
+func TestPublisherDeliveryFailures(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		results []DeliveryResult
+	)
+
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+	publisher := client.NewPublisher(PublisherConfig{
+		OnDelivery: func(r DeliveryResult) {
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, r)
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		is.NoErr(publisher.Publish(context.Background(), TelemetryValue{
+			DeviceID:      "DEV-1",
+			ParameterName: "voltage",
+			Value:         i,
+			Timestamp:     time.Now(),
+		}))
+	}
+
+	is.NoErr(publisher.Flush(context.Background()))
+
+	mu.Lock()
+	is.Equal(len(results), 3)
+	for _, r := range results {
+		is.Equal(r.Err, &ErrUnexpectedResponse{
+			StatusCode: 500,
+			Status:     "500 Internal Server Error",
+		})
+	}
+	mu.Unlock()
+
+	is.Equal(publisher.Stats().Failed, uint64(3))
+	is.NoErr(publisher.Close())
+}
@@ -3,18 +3,70 @@ package aware
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// TelemetryAggregation is how values are combined into each bucket when querying telemetry.
+type TelemetryAggregation string
+
+const (
+	// AggregationNone returns every value without combining them.
+	AggregationNone TelemetryAggregation = ""
+	// AggregationAverage is the mean of the values in each bucket.
+	AggregationAverage TelemetryAggregation = "avg"
+	// AggregationMinimum is the smallest value in each bucket.
+	AggregationMinimum TelemetryAggregation = "min"
+	// AggregationMaximum is the largest value in each bucket.
+	AggregationMaximum TelemetryAggregation = "max"
+	// AggregationSum is the total of the values in each bucket.
+	AggregationSum TelemetryAggregation = "sum"
+	// AggregationCount is the number of values in each bucket.
+	AggregationCount TelemetryAggregation = "count"
+	// AggregationLast is the most recent value in each bucket.
+	AggregationLast TelemetryAggregation = "last"
+)
+
+// TelemetryAggregations are all the aggregations supported when querying telemetry.
+var TelemetryAggregations = []TelemetryAggregation{
+	AggregationAverage,
+	AggregationMinimum,
+	AggregationMaximum,
+	AggregationSum,
+	AggregationCount,
+	AggregationLast,
+}
+
+// GetTelemetryOptions are the available options for the GetTelemetry query.
+type GetTelemetryOptions struct {
+	Start       time.Time
+	End         time.Time
+	Parameters  []string
+	Aggregation TelemetryAggregation
+	Bucket      time.Duration // Required with an aggregation
+	Limit       int           // Maximum values per page, the server default is used when 0
+	Cursor      string        // Next page cursor returned by the previous page
+}
+
+// TelemetryPage is a page of historical telemetry, Next is empty on the last page.
+type TelemetryPage struct {
+	Values []*TelemetryValue `json:"values"`
+	Next   string            `json:"next"`
+}
+
 // PublishTelemetry publishes a value for an individual parameter for a device.
+// The timestamp keeps its fractional seconds, as it does in bulk requests.
 func (c *Client) PublishTelemetry(deviceID string, parameterName string, value interface{}, ts time.Time) error {
 	data := struct {
 		Timestamp     string      `json:"timestamp"`
 		DeviceID      string      `json:"device"`
 		ParameterName string      `json:"parameter"`
 		Value         interface{} `json:"value"`
-	}{formatTime(ts), deviceID, parameterName, value}
+	}{ts.Format(time.RFC3339Nano), deviceID, parameterName, value}
 
 	header := Header{
 		"Accept":       "application/json",
@@ -39,3 +91,104 @@ func (c *Client) PublishTelemetry(deviceID string, parameterName string, value i
 
 	return nil
 }
+
+// GetLatestTelemetry attempts to retrieve the latest value of every parameter for a device.
+func (c *Client) GetLatestTelemetry(deviceID string) ([]*TelemetryValue, error) {
+	url := c.server + "/v1/telemetry/" + deviceID + "/latest"
+
+	res, err := c.request(context.Background(), http.MethodGet, url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, ErrEmptyResult
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, formatUnexpectedResponse(res)
+	}
+
+	var out []*TelemetryValue
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetTelemetry attempts to retrieve a single page of historical telemetry for a device.
+func (c *Client) GetTelemetry(deviceID string, opts GetTelemetryOptions) (*TelemetryPage, error) {
+	query := url.Values{}
+	if !opts.Start.IsZero() {
+		query.Set("start", formatTime(opts.Start))
+	}
+	if !opts.End.IsZero() {
+		query.Set("end", formatTime(opts.End))
+	}
+	if len(opts.Parameters) > 0 {
+		query.Set("parameters", strings.Join(opts.Parameters, ","))
+	}
+	if opts.Aggregation != AggregationNone {
+		if opts.Bucket <= 0 {
+			return nil, fmt.Errorf("a bucket is required to aggregate telemetry")
+		}
+		query.Set("aggregation", string(opts.Aggregation))
+		query.Set("bucket", strconv.FormatInt(int64(opts.Bucket/time.Second), 10)+"s")
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+
+	endpoint := c.server + "/v1/telemetry/" + deviceID
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	res, err := c.request(context.Background(), http.MethodGet, endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, ErrEmptyResult
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, formatUnexpectedResponse(res)
+	}
+
+	var out *TelemetryPage
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetAllTelemetry retrieves every page of historical telemetry for a device, calling fn with
+// each page in turn. Retrieving stops at the first error returned by fn.
+func (c *Client) GetAllTelemetry(deviceID string, opts GetTelemetryOptions, fn func(page *TelemetryPage) error) error {
+	for {
+		page, err := c.GetTelemetry(deviceID, opts)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(page); err != nil {
+			return err
+		}
+
+		if page.Next == "" || page.Next == opts.Cursor {
+			return nil
+		}
+		opts.Cursor = page.Next
+	}
+}
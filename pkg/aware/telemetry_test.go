@@ -0,0 +1,87 @@
+package aware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestGetLatestTelemetry(t *testing.T) {
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.Method, http.MethodGet)
+		is.Equal(r.URL.Path, "/v1/telemetry/DEV-1/latest")
+
+		_, _ = w.Write([]byte(`[
+			{"device": "DEV-1", "parameter": "voltage", "value": 240.5, "timestamp": "2026-01-01T00:00:00Z"},
+			{"device": "DEV-1", "parameter": "closed", "value": true, "timestamp": "2026-01-01T00:00:05Z"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	values, err := client.GetLatestTelemetry("DEV-1")
+	is.NoErr(err)
+	is.Equal(len(values), 2)
+	is.Equal(values[0].ParameterName, "voltage")
+	is.Equal(values[0].Value, 240.5)
+	is.True(values[1].Timestamp.Equal(time.Date(2026, 1, 1, 0, 0, 5, 0, time.UTC)))
+}
+
+func TestGetAllTelemetryPages(t *testing.T) {
+	is := is.New(t)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		is.Equal(r.URL.Path, "/v1/telemetry/DEV-1")
+		is.Equal(r.URL.Query().Get("start"), "2026-01-01T00:00:00Z")
+		is.Equal(r.URL.Query().Get("parameters"), "voltage,current")
+		is.Equal(r.URL.Query().Get("aggregation"), "avg")
+		is.Equal(r.URL.Query().Get("bucket"), "300s")
+
+		page := TelemetryPage{Next: "page-2"}
+		if r.URL.Query().Get("cursor") == "page-2" {
+			page.Next = ""
+		}
+		page.Values = []*TelemetryValue{{DeviceID: "DEV-1", ParameterName: "voltage", Value: 240.0, Timestamp: start}}
+
+		is.NoErr(json.NewEncoder(w).Encode(&page))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL})
+
+	var values []*TelemetryValue
+	err := client.GetAllTelemetry("DEV-1", GetTelemetryOptions{
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Parameters:  []string{"voltage", "current"},
+		Aggregation: AggregationAverage,
+		Bucket:      5 * time.Minute,
+	}, func(page *TelemetryPage) error {
+		values = append(values, page.Values...)
+		return nil
+	})
+	is.NoErr(err)
+	is.Equal(requests, 2)
+	is.Equal(len(values), 2)
+}
+
+func TestGetTelemetryRequiresBucket(t *testing.T) {
+	is := is.New(t)
+
+	client := NewClient(Config{Server: "http://127.0.0.1:0"})
+
+	_, err := client.GetTelemetry("DEV-1", GetTelemetryOptions{Aggregation: AggregationMaximum})
+	is.True(err != nil)
+}
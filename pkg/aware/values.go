@@ -0,0 +1,177 @@
+package aware
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+)
+
+const (
+	waveformFundamental     = 50.0 // Hz
+	waveformSamplesPerCycle = 64
+	waveformCycles          = 4
+	waveformDefaultPeak     = 340.0 // Roughly the peak of a 240V RMS supply
+)
+
+// WaveformValue is the value published for a waveform parameter.
+type WaveformValue struct {
+	SampleRate float64   `json:"sampleRate"`
+	Frequency  float64   `json:"frequency"`
+	Samples    []float64 `json:"samples"`
+}
+
+// SpectrumValue is the value published for a spectrum parameter.
+// Each magnitude is the bin at StartFrequency + index * Resolution.
+type SpectrumValue struct {
+	StartFrequency float64   `json:"startFrequency"`
+	Resolution     float64   `json:"resolution"`
+	Magnitudes     []float64 `json:"values"`
+}
+
+// ObjectTemplate describes the shape of a generated object value.
+// Leaf values are either a DeviceTypeParameterValueType, TemplateDate
+// or a nested ObjectTemplate.
+type ObjectTemplate map[string]interface{}
+
+// TemplateDate is used in an ObjectTemplate to generate the RFC3339 timestamp the
+// object is published at.
+const TemplateDate = "date"
+
+// DefaultObjectTemplate is used for object parameters.
+var DefaultObjectTemplate = ObjectTemplate{
+	"name":      String,
+	"value":     Float,
+	"ok":        Bool,
+	"timestamp": TemplateDate,
+}
+
+// TestReportTemplate is used for object parameters named as a test report.
+var TestReportTemplate = ObjectTemplate{
+	"passed":    Bool,
+	"tester":    String,
+	"timestamp": TemplateDate,
+	"results": ObjectTemplate{
+		"insulationResistance": Float,
+		"earthContinuity":      Float,
+		"tripTime":             Float,
+	},
+}
+
+// Generate builds a random object following the template, to be published at ts.
+func (t ObjectTemplate) Generate(ts time.Time) map[string]interface{} {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]interface{}, len(t))
+	for _, k := range keys {
+		switch v := t[k].(type) {
+		case ObjectTemplate:
+			out[k] = v.Generate(ts)
+		case map[string]interface{}:
+			out[k] = ObjectTemplate(v).Generate(ts)
+		case DeviceTypeParameterValueType:
+			out[k] = generateRandomScalar(v)
+		case string:
+			if v == TemplateDate {
+				out[k] = formatTime(ts)
+			} else {
+				out[k] = generateRandomScalar(DeviceTypeParameterValueType(v))
+			}
+		default:
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
+func generateRandomScalar(valueType DeviceTypeParameterValueType) interface{} {
+	switch valueType {
+	case Float:
+		return generateRandomFloat(0, 100, 2)
+	case Bool:
+		return generateRandomBool()
+	case String:
+		return faker.Word()
+	}
+	return nil
+}
+
+// generateWaveform synthesises a few cycles of a sine wave with some odd harmonics
+// so that it looks like a slightly distorted mains signal.
+func generateWaveform(peak float64) *WaveformValue {
+	if peak <= 0 {
+		peak = waveformDefaultPeak
+	}
+
+	rand.Seed(time.Now().UnixNano())
+	phase := rand.Float64() * 2 * math.Pi
+	harmonics := map[float64]float64{
+		3: rand.Float64() * 0.08,
+		5: rand.Float64() * 0.05,
+		7: rand.Float64() * 0.03,
+	}
+
+	sampleRate := waveformFundamental * waveformSamplesPerCycle
+	samples := make([]float64, waveformSamplesPerCycle*waveformCycles)
+	for i := range samples {
+		t := float64(i) / sampleRate
+		v := math.Sin(2*math.Pi*waveformFundamental*t + phase)
+		for order, ratio := range harmonics {
+			v += ratio * math.Sin(2*math.Pi*waveformFundamental*order*t+phase*order)
+		}
+		noise := (rand.Float64() - 0.5) * 0.01
+		samples[i] = math.Round((v+noise)*peak*100) / 100
+	}
+
+	return &WaveformValue{
+		SampleRate: sampleRate,
+		Frequency:  waveformFundamental,
+		Samples:    samples,
+	}
+}
+
+// generateSpectrum returns the single sided magnitude spectrum of a generated waveform.
+func generateSpectrum(peak float64) *SpectrumValue {
+	waveform := generateWaveform(peak)
+	n := len(waveform.Samples)
+	bins := n / 2
+
+	magnitudes := make([]float64, bins)
+	for k := 0; k < bins; k++ {
+		var sum complex128
+		for i, sample := range waveform.Samples {
+			angle := -2 * math.Pi * float64(k) * float64(i) / float64(n)
+			sum += complex(sample, 0) * cmplx.Exp(complex(0, angle))
+		}
+		magnitude := cmplx.Abs(sum) / float64(n)
+		if k != 0 {
+			magnitude *= 2
+		}
+		magnitudes[k] = math.Round(magnitude*100) / 100
+	}
+
+	return &SpectrumValue{
+		StartFrequency: 0,
+		Resolution:     waveform.SampleRate / float64(n),
+		Magnitudes:     magnitudes,
+	}
+}
+
+// String returns a short summary of the waveform for display.
+func (w *WaveformValue) String() string {
+	return fmt.Sprintf("waveform(%d samples @ %gHz)", len(w.Samples), w.SampleRate)
+}
+
+// String returns a short summary of the spectrum for display.
+func (s *SpectrumValue) String() string {
+	return fmt.Sprintf("spectrum(%d bins @ %gHz)", len(s.Magnitudes), s.Resolution)
+}
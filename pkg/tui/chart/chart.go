@@ -0,0 +1,221 @@
+// Package chart contains text charts for displaying telemetry in a terminal.
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// sparks are the bars used by Sparkline, from lowest to highest.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Series is a named set of values to chart.
+type Series struct {
+	Name   string
+	Unit   string
+	Values []float64
+	From   string // Label for the first value
+	To     string // Label for the last value
+}
+
+// Stats are the summary statistics of a series.
+type Stats struct {
+	Min  float64
+	Max  float64
+	Avg  float64
+	Last float64
+}
+
+// Stats returns the summary statistics of the series, it is the zero value when there are no values.
+func (s Series) Stats() Stats {
+	if len(s.Values) == 0 {
+		return Stats{}
+	}
+
+	stats := Stats{
+		Min:  s.Values[0],
+		Max:  s.Values[0],
+		Last: s.Values[len(s.Values)-1],
+	}
+
+	sum := 0.0
+	for _, v := range s.Values {
+		stats.Min = math.Min(stats.Min, v)
+		stats.Max = math.Max(stats.Max, v)
+		sum += v
+	}
+	stats.Avg = sum / float64(len(s.Values))
+
+	return stats
+}
+
+// Sparkline renders the most recent values that fit in width as a single line of bars.
+func Sparkline(values []float64, width int) string {
+	if width <= 0 || len(values) == 0 {
+		return ""
+	}
+
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	low, high := bounds(values)
+
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if high > low {
+			i = int(math.Round((v - low) / (high - low) * float64(len(sparks)-1)))
+		}
+		b.WriteRune(sparks[i])
+	}
+
+	return b.String()
+}
+
+// LineChart renders the series as a line chart of the given size, including a line of
+// summary statistics, y axis labels in the series unit and x axis labels if set.
+// The most recent values that fit in the width are shown.
+func LineChart(s Series, width, height int) string {
+	const minPlotHeight = 2
+
+	plotHeight := height - 1 // Statistics
+	if s.From != "" || s.To != "" {
+		plotHeight-- // X axis labels
+	}
+
+	if len(s.Values) == 0 || plotHeight < minPlotHeight {
+		return fitLines([]string{s.Name + ": no values to chart"}, width, height)
+	}
+
+	stats := s.Stats()
+	low, high := stats.Min, stats.Max
+
+	labels := make([]string, plotHeight)
+	labelWidth := 0
+	for row := range labels {
+		labels[row] = formatValue(high-(high-low)*float64(row)/float64(plotHeight-1), s.Unit)
+		labelWidth = max(labelWidth, runewidth.StringWidth(labels[row]))
+	}
+
+	const axisWidth = 2 // " ┤"
+	plotWidth := width - labelWidth - axisWidth
+	if plotWidth < 2 {
+		return fitLines([]string{s.Name + ": not enough room to chart"}, width, height)
+	}
+
+	values := s.Values
+	if len(values) > plotWidth {
+		values = values[len(values)-plotWidth:]
+	}
+
+	grid := make([][]rune, plotHeight)
+	for row := range grid {
+		grid[row] = []rune(strings.Repeat(" ", plotWidth))
+	}
+
+	// Rows are counted from the bottom, 0 being the lowest value
+	scale := func(v float64) int {
+		if high == low {
+			return (plotHeight - 1) / 2
+		}
+		return int(math.Round((v - low) / (high - low) * float64(plotHeight-1)))
+	}
+	set := func(row, col int, r rune) {
+		grid[plotHeight-1-row][col] = r
+	}
+
+	for x := 0; x < len(values)-1; x++ {
+		y0, y1 := scale(values[x]), scale(values[x+1])
+		switch {
+		case y0 == y1:
+			set(y0, x, '─')
+		case y0 > y1:
+			set(y0, x, '╮')
+			set(y1, x, '╰')
+		default:
+			set(y0, x, '╯')
+			set(y1, x, '╭')
+		}
+		for y := min(y0, y1) + 1; y < max(y0, y1); y++ {
+			set(y, x, '│')
+		}
+	}
+	set(scale(values[len(values)-1]), len(values)-1, '─')
+
+	lines := make([]string, 0, height)
+	lines = append(lines, fmt.Sprintf("%s  min %s  max %s  avg %s  last %s",
+		s.Name,
+		formatValue(stats.Min, s.Unit),
+		formatValue(stats.Max, s.Unit),
+		formatValue(stats.Avg, s.Unit),
+		formatValue(stats.Last, s.Unit),
+	))
+
+	for row := range grid {
+		label := strings.Repeat(" ", labelWidth-runewidth.StringWidth(labels[row])) + labels[row]
+		lines = append(lines, label+" ┤"+string(grid[row]))
+	}
+
+	if s.From != "" || s.To != "" {
+		gap := plotWidth - runewidth.StringWidth(s.From) - runewidth.StringWidth(s.To)
+		if gap < 1 {
+			gap = 1
+		}
+		lines = append(lines, strings.Repeat(" ", labelWidth+axisWidth)+s.From+strings.Repeat(" ", gap)+s.To)
+	}
+
+	return fitLines(lines, width, height)
+}
+
+// fitLines truncates each line to the width and pads the lines to the height.
+func fitLines(lines []string, width, height int) string {
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+
+	for i, line := range lines {
+		lines[i] = runewidth.Truncate(line, width, "…")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func formatValue(v float64, unit string) string {
+	s := fmt.Sprintf("%.2f", v)
+	if unit != "" {
+		s += " " + unit
+	}
+	return s
+}
+
+func bounds(values []float64) (float64, float64) {
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+	return low, high
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
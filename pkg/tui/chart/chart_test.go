@@ -0,0 +1,57 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/mattn/go-runewidth"
+)
+
+func TestSparkline(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(Sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}, 10), "▁▂▃▄▅▆▇█")
+	is.Equal(Sparkline([]float64{0, 7, 0, 7}, 2), "▁█")
+	is.Equal(Sparkline([]float64{3, 3, 3}, 10), "▁▁▁")
+	is.Equal(Sparkline(nil, 10), "")
+}
+
+func TestStats(t *testing.T) {
+	is := is.New(t)
+
+	stats := Series{Values: []float64{2, 4, 0, 6}}.Stats()
+	is.Equal(stats, Stats{Min: 0, Max: 6, Avg: 3, Last: 6})
+}
+
+func TestLineChart(t *testing.T) {
+	is := is.New(t)
+
+	s := Series{
+		Name:   "Voltage",
+		Unit:   "V",
+		Values: []float64{1, 2, 3, 2, 1},
+		From:   "00:00",
+		To:     "00:04",
+	}
+
+	out := LineChart(s, 40, 6)
+	lines := strings.Split(out, "\n")
+
+	is.Equal(len(lines), 6)
+	is.True(strings.HasPrefix(lines[0], "Voltage  min 1.00 V  max 3.00 V"))
+	is.True(strings.HasPrefix(lines[1], "3.00 V ┤"))
+	is.True(strings.HasPrefix(lines[4], "1.00 V ┤"))
+	is.True(strings.HasSuffix(lines[5], "00:04"))
+
+	for _, line := range lines {
+		is.True(runewidth.StringWidth(line) <= 40)
+	}
+}
+
+func TestLineChartFlat(t *testing.T) {
+	is := is.New(t)
+
+	out := LineChart(Series{Name: "Flat", Values: []float64{5, 5, 5}}, 20, 4)
+	is.True(strings.Contains(out, "───"))
+}
@@ -0,0 +1,145 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+
+	"ampaware.com/cli/pkg/tui/chart"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// showSparklines is true when each numeric column has a sparkline under its header.
+// Tables with their own series function show their trends in the rows instead.
+func (m Model) showSparklines() bool {
+	return m.charts && m.seriesFunc == nil
+}
+
+func (m Model) sparklinesView() string {
+	rows := m.chartRows()
+
+	s := make([]string, 0, len(m.cols))
+	for i, col := range m.cols {
+		values, _ := m.columnValues(i, rows)
+		style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true)
+		s = append(s, m.styles.Cell.Render(style.Render(chart.Sparkline(values, col.Width))))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Left, s...)
+}
+
+func (m Model) chartView() string {
+	series, ok := m.selectedSeries()
+	if !ok {
+		series = chart.Series{Name: "Nothing to chart"}
+	}
+
+	return chart.LineChart(series, m.viewport.Width, m.chartHeight)
+}
+
+// selectedSeries returns the series for the selected row when the table has a series
+// function, otherwise the series of the chart column.
+func (m Model) selectedSeries() (chart.Series, bool) {
+	if m.seriesFunc != nil {
+		if len(m.rows) == 0 {
+			return chart.Series{}, false
+		}
+		return m.seriesFunc(m.cursor)
+	}
+
+	if m.chartColumn >= len(m.cols) {
+		return chart.Series{}, false
+	}
+
+	rows := m.chartRows()
+	values, used := m.columnValues(m.chartColumn, rows)
+	if len(values) == 0 {
+		return chart.Series{}, false
+	}
+
+	col := m.cols[m.chartColumn]
+	series := chart.Series{
+		Name:   col.Title,
+		Unit:   col.Unit,
+		Values: values,
+	}
+
+	// The first column labels the x axis, normally the time of the row
+	if m.chartColumn != 0 && len(m.rows[used[0]]) > 0 {
+		series.From = m.rows[used[0]][0]
+		series.To = m.rows[used[len(used)-1]][0]
+	}
+
+	return series, true
+}
+
+// chartRows returns the index of every row to chart. When there is a group column
+// only rows in the same group as the selected row are included.
+func (m Model) chartRows() []int {
+	group := -1
+	for i, col := range m.cols {
+		if col.Group {
+			group = i
+			break
+		}
+	}
+
+	rows := make([]int, 0, len(m.rows))
+	for i, row := range m.rows {
+		if group >= 0 && m.cursor < len(m.rows) && group < len(row) && group < len(m.rows[m.cursor]) &&
+			row[group] != m.rows[m.cursor][group] {
+			continue
+		}
+		rows = append(rows, i)
+	}
+	return rows
+}
+
+// columnValues returns the numeric values of the column in the given rows and the
+// rows they came from. Failed values and cells that aren't numbers are skipped.
+func (m Model) columnValues(col int, rows []int) ([]float64, []int) {
+	var (
+		values []float64
+		used   []int
+	)
+	for _, i := range rows {
+		if col >= len(m.rows[i]) {
+			continue
+		}
+		if v, ok := parseCell(m.rows[i][col]); ok {
+			values = append(values, v)
+			used = append(used, i)
+		}
+	}
+	return values, used
+}
+
+// moveChartColumn moves the chart to the next column in the direction that has numeric values.
+func (m *Model) moveChartColumn(direction int) {
+	if m.seriesFunc != nil || len(m.cols) == 0 {
+		return
+	}
+
+	rows := m.chartRows()
+	for i := 1; i < len(m.cols); i++ {
+		col := ((m.chartColumn+direction*i)%len(m.cols) + len(m.cols)) % len(m.cols)
+		if values, _ := m.columnValues(col, rows); len(values) > 0 {
+			m.chartColumn = col
+			return
+		}
+	}
+}
+
+// parseCell parses a cell as a number, ignoring any changed prefix and a unit following the number.
+func parseCell(cell string) (float64, bool) {
+	if strings.HasPrefix(cell, FailedCellPrefix) {
+		return 0, false
+	}
+	cell = strings.TrimPrefix(cell, ChangedCellPrefix)
+
+	fields := strings.Fields(cell)
+	if len(fields) == 0 {
+		return 0, false
+	}
+
+	v, err := strconv.ParseFloat(fields[0], 64)
+	return v, err == nil
+}
@@ -1,6 +1,9 @@
 package table
 
-import "github.com/charmbracelet/bubbles/help"
+import (
+	"ampaware.com/cli/pkg/tui/chart"
+	"github.com/charmbracelet/bubbles/help"
+)
 
 // WithColumns sets the table columns (headers).
 func WithColumns(cols []Column) Option {
@@ -80,10 +83,61 @@ func WithRefresh(fn func() ([]Column, []Row)) Option {
 	}
 }
 
-// WithAppending allows setting of a pointer to append from on command.
-func WithAppending(row *Row) Option {
+// WithRowChannel appends every row received on the channel to the table.
+func WithRowChannel(rows <-chan Row) Option {
 	return func(m *Model) {
-		m.appendRow = row
+		m.rowChannel = rows
+	}
+}
+
+// WithMaxRows limits the number of rows kept, the oldest rows are dropped as rows are appended.
+func WithMaxRows(n int) Option {
+	return func(m *Model) {
+		m.maxRows = n
+	}
+}
+
+// WithSnapshotChannel replaces every row of the table with each set of rows received on the channel.
+func WithSnapshotChannel(snapshots <-chan []Row) Option {
+	return func(m *Model) {
+		m.snapshotChannel = snapshots
+	}
+}
+
+// WithCharts adds a sparkline under the header of each numeric column and a chart of the
+// selected column, of the given height, that is toggled with the chart key.
+func WithCharts(height int) Option {
+	return func(m *Model) {
+		m.charts = true
+		m.chartHeight = height
+		m.KeyMap.ToggleChart.SetEnabled(true)
+		m.KeyMap.PrevColumn.SetEnabled(m.seriesFunc == nil)
+		m.KeyMap.NextColumn.SetEnabled(m.seriesFunc == nil)
+	}
+}
+
+// WithChartSeries charts the series returned for the selected row instead of a column,
+// it must be used with WithCharts.
+func WithChartSeries(fn func(row int) (chart.Series, bool)) Option {
+	return func(m *Model) {
+		m.seriesFunc = fn
+		m.KeyMap.PrevColumn.SetEnabled(false)
+		m.KeyMap.NextColumn.SetEnabled(false)
+	}
+}
+
+// WithStatus sets a function to describe the current status in the footer.
+func WithStatus(fn func() string) Option {
+	return func(m *Model) {
+		m.statusFunc = fn
+	}
+}
+
+// WithActions adds key bindings that run the actions, the table doesn't change when they run
+// so the actions should describe their effect in the status.
+func WithActions(actions ...Action) Option {
+	return func(m *Model) {
+		m.actions = append(m.actions, actions...)
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"ampaware.com/cli/internal/utils"
+	"ampaware.com/cli/pkg/tui/chart"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -37,20 +38,38 @@ type Model struct {
 	rowsToReRender map[int]struct{}
 
 	refreshFunc func() ([]Column, []Row)
+	statusFunc  func() string
 	copyIndex   int
 
-	appendRow *Row
+	rowChannel      <-chan Row
+	snapshotChannel <-chan []Row
+	maxRows         int
+
+	charts      bool
+	showChart   bool
+	chartHeight int
+	chartColumn int
+	seriesFunc  func(row int) (chart.Series, bool)
+
+	actions []Action
 
 	viewport viewport.Model
 }
 
-// CommandMessage is a type to efficiently contain messages to send to the table from an external source.
-type CommandMessage byte
+// FailedCellPrefix marks a cell as failed, failed cells are rendered with the Failed style.
+const FailedCellPrefix = "✗ "
 
-const (
-	// AppendReady is used to let the table know a new row is ready to append.
-	AppendReady CommandMessage = iota
-)
+// ChangedCellPrefix marks a cell as changed, changed cells are rendered with the Changed style.
+const ChangedCellPrefix = "● "
+
+// rowMsg is a row received from the row channel.
+type rowMsg Row
+
+// snapshotMsg is every row received from the snapshot channel.
+type snapshotMsg []Row
+
+// rowChannelClosedMsg is sent once the row channel has been closed.
+type rowChannelClosedMsg struct{}
 
 // Row represents one line in the table.
 type Row []string
@@ -59,6 +78,8 @@ type Row []string
 type Column struct {
 	Title string
 	Width int
+	Unit  string // Unit of the values in the column, shown on the chart
+	Group bool   // Only rows with the same value as the selected row in this column are charted together
 }
 
 // KeyMap defines keybindings. It satisfies to the help.KeyMap interface, which
@@ -80,6 +101,30 @@ type KeyMap struct {
 	Copy         key.Binding
 	Paste        key.Binding
 	ToggleHelp   key.Binding
+	ToggleChart  key.Binding
+	PrevColumn   key.Binding
+	NextColumn   key.Binding
+}
+
+// Action is a key binding that runs a function with the key pressed, it is shown in the help.
+type Action struct {
+	Binding key.Binding
+	Run     func(key string)
+}
+
+// actionKeyMap adds the actions to the help of the key map.
+type actionKeyMap struct {
+	KeyMap
+	actions []Action
+}
+
+// FullHelp returns the keybindings of the key map followed by the actions.
+func (k actionKeyMap) FullHelp() [][]key.Binding {
+	bindings := make([]key.Binding, 0, len(k.actions))
+	for _, action := range k.actions {
+		bindings = append(bindings, action.Binding)
+	}
+	return append(k.KeyMap.FullHelp(), bindings)
 }
 
 // Styles contains style definitions for this list component. By default, these
@@ -89,6 +134,8 @@ type Styles struct {
 	Cell     lipgloss.Style
 	Selected lipgloss.Style
 	Footer   lipgloss.Style
+	Failed   lipgloss.Style
+	Changed  lipgloss.Style
 }
 
 // Option is used to set options in New.
@@ -154,6 +201,21 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("?"),
 			key.WithHelp("?", "toggle help"),
 		),
+		ToggleChart: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "toggle chart"),
+			key.WithDisabled(),
+		),
+		PrevColumn: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "chart previous column"),
+			key.WithDisabled(),
+		),
+		NextColumn: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "chart next column"),
+			key.WithDisabled(),
+		),
 	}
 }
 
@@ -171,6 +233,7 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.PageUp, k.PageDown},
 		{k.GotoTop, k.GotoBottom},
 		{k.Execute, k.Refresh},
+		{k.ToggleChart, k.PrevColumn, k.NextColumn},
 		{k.ToggleHelp, k.Exit},
 	}
 }
@@ -188,7 +251,9 @@ func DefaultStyles() Styles {
 			BorderTop(true).
 			BorderBottom(true).
 			Bold(false),
-		Cell: lipgloss.NewStyle().Padding(0, 1),
+		Cell:    lipgloss.NewStyle().Padding(0, 1),
+		Failed:  lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+		Changed: lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true),
 		Footer: lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("240")).
@@ -214,14 +279,8 @@ func New(opts ...Option) Model {
 	// TODO: Add an option for columns to overflow
 	// TODO: Show help
 	// TODO: Value to Clipboard
-	// TODO: Method to Append for Telemetry Generation!
 	// TODO: Better status of table - to use in footer
 
-	// Maybe use channels for comms
-
-	// TODO: WithAppend() could take a channel and a pointer to a []Row
-	// When a new messages comes on the channel we read from the pointer
-
 	m := Model{
 		cursor:   0,
 		viewport: viewport.New(0, 20),
@@ -245,12 +304,53 @@ func New(opts ...Option) Model {
 
 // Init is the Bubble Tea entrypoint.
 func (m Model) Init() tea.Cmd {
-	return nil
+	var cmds []tea.Cmd
+	if m.rowChannel != nil {
+		cmds = append(cmds, m.waitForRow())
+	}
+	if m.snapshotChannel != nil {
+		cmds = append(cmds, m.waitForSnapshot())
+	}
+	return tea.Batch(cmds...)
+}
+
+// waitForRow waits for the next row from the row channel.
+func (m Model) waitForRow() tea.Cmd {
+	rows := m.rowChannel
+	return func() tea.Msg {
+		row, ok := <-rows
+		if !ok {
+			return rowChannelClosedMsg{}
+		}
+		return rowMsg(row)
+	}
+}
+
+// waitForSnapshot waits for the next set of rows from the snapshot channel.
+func (m Model) waitForSnapshot() tea.Cmd {
+	snapshots := m.snapshotChannel
+	return func() tea.Msg {
+		rows, ok := <-snapshots
+		if !ok {
+			return rowChannelClosedMsg{}
+		}
+		return snapshotMsg(rows)
+	}
 }
 
 // Update is the Bubble Tea update loop.
 // nolint:gocyclo // This requires refactoring to simplify.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Rows are appended even when not focused, otherwise the channel would stop being read
+	if msg, ok := msg.(rowMsg); ok {
+		m.AppendRow(Row(msg))
+		return m, m.waitForRow()
+	}
+	if msg, ok := msg.(snapshotMsg); ok {
+		m.ReplaceRows(msg)
+		return m, m.waitForSnapshot()
+	}
+
 	if !m.focus {
 		return m, nil
 	}
@@ -258,12 +358,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
-	case CommandMessage:
-		{
-			if msg == AppendReady {
-				m.AppendRow()
-			}
-		}
 	case tea.WindowSizeMsg:
 		requiredPadding := 0
 		requiredPadding += 3 // Headers
@@ -271,6 +365,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.helpEnabled {
 			requiredPadding++ // Short Help
 		}
+		if m.showSparklines() {
+			requiredPadding++ // Sparklines
+		}
+		if m.showChart {
+			requiredPadding += m.chartHeight
+		}
 		m.SetHeight(msg.Height - requiredPadding)
 		m.SetWidth(msg.Width)
 		m.help.Width = msg.Width
@@ -308,6 +408,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(
 				tea.Printf("Let's go to %s!", m.SelectedRow()[1]),
 			)
+		case key.Matches(msg, m.KeyMap.ToggleChart):
+			if m.showChart {
+				m.SetHeight(m.viewport.Height + m.chartHeight)
+			} else {
+				m.SetHeight(m.viewport.Height - m.chartHeight)
+				if values, _ := m.columnValues(m.chartColumn, m.chartRows()); len(values) == 0 {
+					m.moveChartColumn(1)
+				}
+			}
+			m.showChart = !m.showChart
+		case key.Matches(msg, m.KeyMap.PrevColumn):
+			m.moveChartColumn(-1)
+		case key.Matches(msg, m.KeyMap.NextColumn):
+			m.moveChartColumn(1)
 		case key.Matches(msg, m.KeyMap.ToggleHelp):
 			if m.helpEnabled {
 				if m.help.ShowAll {
@@ -317,6 +431,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				m.help.ShowAll = !m.help.ShowAll
 			}
+		default:
+			for _, action := range m.actions {
+				if key.Matches(msg, action.Binding) {
+					action.Run(msg.String())
+				}
+			}
 		}
 	}
 
@@ -344,10 +464,16 @@ func (m *Model) Blur() {
 // View renders the component.
 func (m Model) View() string {
 	view := m.headersView()
+	if m.showSparklines() {
+		view += "\n" + m.sparklinesView()
+	}
 	view += "\n" + m.viewport.View()
+	if m.showChart {
+		view += "\n" + m.chartView()
+	}
 	view += "\n" + m.footersView()
 	if m.helpEnabled {
-		view += "\n" + m.help.View(m.KeyMap)
+		view += "\n" + m.help.View(actionKeyMap{KeyMap: m.KeyMap, actions: m.actions})
 	}
 	return view
 }
@@ -375,7 +501,9 @@ func (m *Model) UpdateViewport() {
 		m.renderAllRows = false
 	} else {
 		for i := range m.rowsToReRender {
-			m.renderedRows[i] = m.renderRow(i)
+			if i < len(m.renderedRows) {
+				m.renderedRows[i] = m.renderRow(i)
+			}
 		}
 	}
 
@@ -454,11 +582,44 @@ func (m *Model) Refresh() {
 	m.UpdateViewport()
 }
 
-// AppendRow gets the row from the appendRow pointer and adds it to
-// the existing data.
-func (m *Model) AppendRow() {
-	m.rows = append(m.rows, *m.appendRow)
+// AppendRow adds the row to the existing data, dropping the oldest rows when
+// there are more than the maximum.
+func (m *Model) AppendRow(row Row) {
+	m.rows = append(m.rows, row)
 	m.renderedRows = append(m.renderedRows, m.renderRow(len(m.rows)-1))
+	if m.maxRows > 0 && len(m.rows) > m.maxRows {
+		m.dropRows(len(m.rows) - m.maxRows)
+	}
+	m.UpdateViewport()
+}
+
+// dropRows removes the first n rows, keeping the same rows selected and in view.
+func (m *Model) dropRows(n int) {
+	// The dropped rows are freed once appending outgrows the slice
+	m.rows = m.rows[n:]
+	if len(m.renderedRows) >= n {
+		m.renderedRows = m.renderedRows[n:]
+	} else {
+		m.renderAllRows = true
+	}
+
+	reRender := make(map[int]struct{}, len(m.rowsToReRender))
+	for i := range m.rowsToReRender {
+		if i >= n {
+			reRender[i-n] = struct{}{}
+		}
+	}
+	m.rowsToReRender = reRender
+
+	m.cursor = max(m.cursor-n, 0)
+	m.viewport.SetYOffset(max(m.viewport.YOffset-n, 0))
+}
+
+// ReplaceRows replaces every row, keeping the cursor in place where possible.
+func (m *Model) ReplaceRows(rows []Row) {
+	m.rows = rows
+	m.cursor = clamp(m.cursor, 0, max(len(m.rows)-1, 0))
+	m.renderAllRows = true
 	m.UpdateViewport()
 }
 
@@ -596,8 +757,11 @@ func (m *Model) FromValues(value, separator string) {
 
 func (m Model) headersView() string {
 	s := make([]string, 0, len(m.cols))
-	for _, col := range m.cols {
+	for i, col := range m.cols {
 		style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true)
+		if m.showChart && m.seriesFunc == nil && i == m.chartColumn {
+			style = style.Underline(true)
+		}
 		renderedCell := style.Render(runewidth.Truncate(col.Title, col.Width, "…"))
 		s = append(s, m.styles.Header.Render(renderedCell))
 	}
@@ -606,7 +770,11 @@ func (m Model) headersView() string {
 
 func (m Model) footersView() string {
 	style := lipgloss.NewStyle().Width(m.viewport.Width).MaxWidth(m.viewport.Width)
-	rendered := style.Render(fmt.Sprintf("Showing %d entries", len(m.rows)))
+	status := fmt.Sprintf("Showing %d entries", len(m.rows))
+	if m.statusFunc != nil {
+		status += " | " + m.statusFunc()
+	}
+	rendered := style.Render(status)
 	return m.styles.Footer.Render(rendered)
 }
 
@@ -614,6 +782,14 @@ func (m *Model) renderRow(rowID int) string {
 	s := make([]string, 0, len(m.cols))
 	for i, value := range m.rows[rowID] {
 		style := lipgloss.NewStyle().Width(m.cols[i].Width).MaxWidth(m.cols[i].Width).Inline(true)
+		if rowID != m.cursor {
+			switch {
+			case strings.HasPrefix(value, FailedCellPrefix):
+				style = style.Inherit(m.styles.Failed)
+			case strings.HasPrefix(value, ChangedCellPrefix):
+				style = style.Inherit(m.styles.Changed)
+			}
+		}
 		renderedCell := m.styles.Cell.Render(style.Render(runewidth.Truncate(value, m.cols[i].Width, "…")))
 		s = append(s, renderedCell)
 	}
@@ -0,0 +1,342 @@
+// Package tree contains an expandable tree widget and a plain text renderer for hierarchies.
+package tree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Node is an item in the tree.
+type Node struct {
+	Label    string
+	Detail   string // Shown dimmed after the label
+	Children []*Node
+
+	expanded bool
+}
+
+// Expand expands the node and every node under it.
+func (n *Node) Expand() {
+	n.expanded = true
+	for _, child := range n.Children {
+		child.Expand()
+	}
+}
+
+// Collapse collapses the node and every node under it.
+func (n *Node) Collapse() {
+	n.expanded = false
+	for _, child := range n.Children {
+		child.Collapse()
+	}
+}
+
+// Count returns the number of nodes under the node, including itself.
+func (n *Node) Count() int {
+	count := 1
+	for _, child := range n.Children {
+		count += child.Count()
+	}
+	return count
+}
+
+// Render renders the nodes as indented text, with branches drawn between them.
+func Render(nodes []*Node) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		render(&b, n, "", "")
+	}
+	return b.String()
+}
+
+func render(b *strings.Builder, n *Node, prefix, childPrefix string) {
+	b.WriteString(prefix + n.Label)
+	if n.Detail != "" {
+		b.WriteString(" " + n.Detail)
+	}
+	b.WriteString("\n")
+
+	for i, child := range n.Children {
+		if i == len(n.Children)-1 {
+			render(b, child, childPrefix+"└── ", childPrefix+"    ")
+		} else {
+			render(b, child, childPrefix+"├── ", childPrefix+"│   ")
+		}
+	}
+}
+
+// KeyMap defines keybindings.
+type KeyMap struct {
+	LineUp      key.Binding
+	LineDown    key.Binding
+	Expand      key.Binding
+	Collapse    key.Binding
+	Toggle      key.Binding
+	ExpandAll   key.Binding
+	CollapseAll key.Binding
+	Exit        key.Binding
+}
+
+// DefaultKeyMap returns a default set of keybindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		LineUp: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		LineDown: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Expand: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "expand"),
+		),
+		Collapse: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "collapse"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys("enter", " "),
+			key.WithHelp("enter", "toggle"),
+		),
+		ExpandAll: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "expand all"),
+		),
+		CollapseAll: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "collapse all"),
+		),
+		Exit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q/ctrl+c", "exit"),
+		),
+	}
+}
+
+// Styles contains style definitions for the tree.
+type Styles struct {
+	Selected lipgloss.Style
+	Detail   lipgloss.Style
+	Footer   lipgloss.Style
+}
+
+// DefaultStyles returns a set of default style definitions for the tree.
+func DefaultStyles() Styles {
+	return Styles{
+		Selected: lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")),
+		Detail:   lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
+		Footer: lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			BorderTop(true),
+	}
+}
+
+// Model is an expandable tree of nodes, the roots start expanded.
+type Model struct {
+	KeyMap KeyMap
+
+	roots  []*Node
+	styles Styles
+	status string
+
+	cursor int
+	offset int
+	width  int
+	height int
+}
+
+// line is a visible node and how deep it is in the tree.
+type line struct {
+	node   *Node
+	depth  int
+	parent int // Index of the parent line, -1 for roots
+}
+
+// New creates a tree of the nodes, status is shown in the footer.
+func New(roots []*Node, status string) Model {
+	for _, root := range roots {
+		root.expanded = true
+	}
+
+	return Model{
+		KeyMap: DefaultKeyMap(),
+		roots:  roots,
+		styles: DefaultStyles(),
+		status: status,
+		height: 20,
+	}
+}
+
+// Init is the Bubble Tea entrypoint.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update is the Bubble Tea update loop.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	lines := m.lines()
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		const footerHeight = 2
+		m.width = msg.Width
+		m.height = msg.Height - footerHeight
+	case tea.KeyMsg:
+		if len(lines) == 0 {
+			if key.Matches(msg, m.KeyMap.Exit) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.KeyMap.LineUp):
+			m.cursor--
+		case key.Matches(msg, m.KeyMap.LineDown):
+			m.cursor++
+		case key.Matches(msg, m.KeyMap.Expand):
+			if n := lines[m.cursor].node; len(n.Children) > 0 {
+				if n.expanded {
+					m.cursor++
+				}
+				n.expanded = true
+			}
+		case key.Matches(msg, m.KeyMap.Collapse):
+			// Collapsing a leaf or collapsed node moves to its parent
+			if l := lines[m.cursor]; l.node.expanded && len(l.node.Children) > 0 {
+				l.node.expanded = false
+			} else if l.parent >= 0 {
+				m.cursor = l.parent
+			}
+		case key.Matches(msg, m.KeyMap.Toggle):
+			if n := lines[m.cursor].node; len(n.Children) > 0 {
+				n.expanded = !n.expanded
+			}
+		case key.Matches(msg, m.KeyMap.ExpandAll):
+			for _, root := range m.roots {
+				root.Expand()
+			}
+		case key.Matches(msg, m.KeyMap.CollapseAll):
+			for _, root := range m.roots {
+				root.Collapse()
+			}
+			m.cursor = 0
+		case key.Matches(msg, m.KeyMap.Exit):
+			return m, tea.Quit
+		}
+	}
+
+	m.cursor = clamp(m.cursor, 0, len(m.lines())-1)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.height > 0 && m.cursor >= m.offset+m.height {
+		m.offset = m.cursor - m.height + 1
+	}
+
+	return m, nil
+}
+
+// View renders the component.
+func (m Model) View() string {
+	lines := m.lines()
+
+	var b strings.Builder
+	for i := m.offset; i < len(lines) && i < m.offset+m.height; i++ {
+		b.WriteString(m.renderLine(lines[i], i == m.cursor))
+		b.WriteString("\n")
+	}
+	for i := len(lines) - m.offset; i < m.height; i++ {
+		b.WriteString("\n")
+	}
+
+	status := fmt.Sprintf("%d of %d shown", len(lines), m.count())
+	if m.status != "" {
+		status += " | " + m.status
+	}
+	b.WriteString(m.styles.Footer.Width(m.width).Render(status))
+
+	return b.String()
+}
+
+func (m Model) renderLine(l line, selected bool) string {
+	marker := "  "
+	if len(l.node.Children) > 0 {
+		marker = "▸ "
+		if l.node.expanded {
+			marker = "▾ "
+		}
+	}
+
+	label := strings.Repeat("  ", l.depth) + marker + l.node.Label
+	detail := l.node.Detail
+	if m.width > 0 {
+		label = runewidth.Truncate(label, m.width, "…")
+		detail = runewidth.Truncate(detail, max(m.width-runewidth.StringWidth(label)-1, 0), "…")
+	}
+
+	if selected {
+		label = m.styles.Selected.Render(label)
+	}
+	if detail != "" {
+		label += " " + m.styles.Detail.Render(detail)
+	}
+
+	return label
+}
+
+// lines returns every visible node in order.
+func (m Model) lines() []line {
+	var out []line
+
+	var walk func(n *Node, depth, parent int)
+	walk = func(n *Node, depth, parent int) {
+		out = append(out, line{node: n, depth: depth, parent: parent})
+		if !n.expanded {
+			return
+		}
+		index := len(out) - 1
+		for _, child := range n.Children {
+			walk(child, depth+1, index)
+		}
+	}
+	for _, root := range m.roots {
+		walk(root, 0, -1)
+	}
+
+	return out
+}
+
+func (m Model) count() int {
+	count := 0
+	for _, root := range m.roots {
+		count += root.Count()
+	}
+	return count
+}
+
+func clamp(v, low, high int) int {
+	if v > high {
+		v = high
+	}
+	if v < low {
+		v = low
+	}
+	return v
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,85 @@
+package tree
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+)
+
+func nodes() []*Node {
+	return []*Node{
+		{
+			Label: "Site",
+			Children: []*Node{
+				{Label: "Line 1", Detail: "(2 devices)", Children: []*Node{{Label: "Motor"}}},
+				{Label: "Line 2"},
+			},
+		},
+		{Label: "Office"},
+	}
+}
+
+func TestRender(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(Render(nodes()), `Site
+├── Line 1 (2 devices)
+│   └── Motor
+└── Line 2
+Office
+`)
+	is.Equal(Render(nil), "")
+}
+
+func TestUpdate(t *testing.T) {
+	is := is.New(t)
+
+	press := func(m Model, k string) Model {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		out, _ := m.Update(msg)
+		return out.(Model)
+	}
+
+	roots := nodes()
+	m := New(roots, "")
+	is.Equal(len(m.lines()), 4) // Roots start expanded
+	is.Equal(m.count(), 5)
+
+	m = press(m, "j")
+	m = press(m, "l")
+	is.Equal(len(m.lines()), 5) // Line 1 expanded
+
+	m = press(m, "l")
+	is.Equal(m.lines()[m.cursor].node.Label, "Motor") // Expanding an expanded node moves into it
+
+	m = press(m, "h")
+	is.Equal(m.lines()[m.cursor].node.Label, "Line 1") // Collapsing a leaf moves to its parent
+
+	m = press(m, "enter")
+	is.Equal(len(m.lines()), 4)
+
+	m = press(m, "C")
+	is.Equal(len(m.lines()), 2)
+	is.Equal(m.cursor, 0)
+
+	m = press(m, "E")
+	is.Equal(len(m.lines()), 5)
+
+	for i := 0; i < 10; i++ {
+		m = press(m, "j")
+	}
+	is.Equal(m.cursor, 4) // Cursor stays on the last line
+}
+
+func TestUpdateEmpty(t *testing.T) {
+	is := is.New(t)
+
+	m := New(nil, "")
+	out, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	is.Equal(out.(Model).cursor, 0)
+}